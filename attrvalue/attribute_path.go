@@ -0,0 +1,45 @@
+package attrvalue
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// parseAttributePath splits an attribute name into the name of the attribute and the path to a value inside it,
+// e.g. `app_settings["WEBSITE_RUN_FROM_PACKAGE"]` or `identity.type` for map and object attributes.
+// The path is empty for a plain attribute name.
+func parseAttributePath(attributeName string) (string, hcl.Traversal, error) {
+	traversal, diags := hclsyntax.ParseTraversalAbs([]byte(attributeName), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", nil, fmt.Errorf("invalid attribute %q: %s", attributeName, diags.Error())
+	}
+	return traversal.RootName(), traversal[1:], nil
+}
+
+// valueAtPath returns the value at the path inside the value of an attribute.
+// A path that does not exist in the value, e.g. a missing map key, gives a null value, like an attribute that is not specified.
+// A known string is decoded as JSON first, for attributes set with jsonencode(), e.g. the `body` of an azapi resource.
+func valueAtPath(val cty.Value, path hcl.Traversal) cty.Value {
+	if len(path) == 0 {
+		return val
+	}
+	if val.Type() == cty.String && val.IsKnown() && !val.IsNull() {
+		src := []byte(val.AsString())
+		ty, err := ctyjson.ImpliedType(src)
+		if err != nil {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		if val, err = ctyjson.Unmarshal(src, ty); err != nil {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+	}
+	v, diags := path.TraverseRel(val)
+	if diags.HasErrors() {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return v
+}
@@ -0,0 +1,177 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestAttributePathValueRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "object attribute correct",
+			rule: attrvalue.NewSimpleRule("foo", "identity.type", []string{"SystemAssigned"}, "", true, ""),
+			content: `
+	resource "foo" "example" {
+		identity = {
+			type = "SystemAssigned"
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "object attribute incorrect",
+			rule: attrvalue.NewSimpleRule("foo", "identity.type", []string{"SystemAssigned"}, "", false, ""),
+			content: `
+	variable "identity" {
+		type = object({
+			type         = string
+			identity_ids = optional(list(string))
+		})
+		default = {
+			type = "UserAssigned"
+		}
+	}
+	resource "foo" "example" {
+		identity = var.identity
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleRule("foo", "identity.type", []string{"SystemAssigned"}, "", false, ""),
+					Message: "`var.identity (default) -> foo.example.identity.type`: UserAssigned is an invalid attribute value of `identity.type` - expecting (one of) [SystemAssigned]",
+				},
+			},
+		},
+		{
+			name: "map key incorrect",
+			rule: attrvalue.NewForbiddenRule("foo", `app_settings["WEBSITE_RUN_FROM_PACKAGE"]`, []string{"0"}, "1", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		app_settings = {
+			WEBSITE_RUN_FROM_PACKAGE = "0"
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewForbiddenRule("foo", `app_settings["WEBSITE_RUN_FROM_PACKAGE"]`, []string{"0"}, "1", "", false, ""),
+					Message: "0 is a forbidden attribute value of `app_settings[\"WEBSITE_RUN_FROM_PACKAGE\"]` - use 1 instead",
+				},
+			},
+		},
+		{
+			name: "map key missing",
+			rule: attrvalue.NewPatternRule("foo", `app_settings["WEBSITE_RUN_FROM_PACKAGE"]`, "^1$", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		app_settings = {
+			FUNCTIONS_WORKER_RUNTIME = "dotnet"
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "map key missing must exist",
+			rule: attrvalue.NewPatternRule("foo", `app_settings["WEBSITE_RUN_FROM_PACKAGE"]`, "^1$", "", true, ""),
+			content: `
+	resource "foo" "example" {
+		app_settings = {
+			FUNCTIONS_WORKER_RUNTIME = "dotnet"
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewPatternRule("foo", `app_settings["WEBSITE_RUN_FROM_PACKAGE"]`, "^1$", "", true, ""),
+					Message: "The attribute `app_settings[\"WEBSITE_RUN_FROM_PACKAGE\"]` must be specified",
+				},
+			},
+		},
+		{
+			name: "number inside an object in a nested block",
+			rule: attrvalue.NewRangeNestedBlockRule("foo", "site_config", "health_check.eviction_time_in_min", attrvalue.Inclusive(2), attrvalue.Inclusive(10), "", false, ""),
+			content: `
+	resource "foo" "example" {
+		site_config {
+			health_check = {
+				path                 = "/health"
+				eviction_time_in_min = 30
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRangeNestedBlockRule("foo", "site_config", "health_check.eviction_time_in_min", attrvalue.Inclusive(2), attrvalue.Inclusive(10), "", false, ""),
+					Message: "30 is an invalid attribute value of `health_check.eviction_time_in_min` - expecting a value <= 10",
+				},
+			},
+		},
+		{
+			name: "unknown object",
+			rule: attrvalue.NewSimpleRule("foo", "identity.type", []string{"SystemAssigned"}, "", true, ""),
+			content: `
+	variable "identity" {
+		type = object({ type = string })
+	}
+	resource "foo" "example" {
+		identity = var.identity
+	}`,
+			expected: helper.Issues{},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
+
+func TestInvalidAttributePath(t *testing.T) {
+	testCases := []struct {
+		name string
+		rule tflint.Rule
+	}{
+		{
+			name: "value rule",
+			rule: attrvalue.NewSimpleRule("azurerm_linux_web_app", `app_settings["WEBSITE_RUN_FROM_PACKAGE"`, []string{"1"}, "", false, "house_run_from_package"),
+		},
+		{
+			name: "must exist rule",
+			rule: attrvalue.NewMustExistRule("azurerm_linux_web_app", `app_settings["WEBSITE_RUN_FROM_PACKAGE"`, "", "house_run_from_package"),
+		},
+	}
+
+	content := `
+	resource "azurerm_linux_web_app" "example" {
+		app_settings = {
+			WEBSITE_RUN_FROM_PACKAGE = "0"
+		}
+	}`
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err == nil {
+				t.Fatal("expected an error for the invalid attribute path")
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package attrvalue
+
+import (
+	"regexp"
+)
+
+// AzapiResourceType is the resource type of the AzAPI provider that manages any Azure resource,
+// given by its ARM type and API version, e.g. `type = "Microsoft.Storage/storageAccounts@2023-01-01"`, and its `body`.
+const AzapiResourceType = "azapi_resource"
+
+// armTypeSetter is implemented by the attribute value rules through baseValue.
+type armTypeSetter interface {
+	setArmType(string)
+}
+
+// ForArmType limits the rule to the azapi resources of the given ARM type, whatever their API version, and returns it.
+// The ARM type is compared without case, e.g. `Microsoft.Storage/storageAccounts`.
+// The attribute of the rule is then usually a property path inside `body`, e.g. `body.sku.name`, see valueAtPath.
+func ForArmType[R armTypeSetter](rule R, armType string) R {
+	rule.setArmType(armType)
+	return rule
+}
+
+func (b *baseValue) setArmType(armType string) {
+	b.armType = armType
+}
+
+// GetArmType returns the ARM type of the azapi resources the rule is limited to, empty if it is not limited to one.
+func (b baseValue) GetArmType() string {
+	return b.armType
+}
+
+// conditions returns the conditions a resource must meet to be checked: the condition of the rule and the ARM type.
+func (b baseValue) conditions() []*Condition {
+	var conditions []*Condition
+	if b.condition != nil {
+		conditions = append(conditions, b.condition)
+	}
+	if b.armType != "" {
+		conditions = append(conditions, &Condition{
+			AttributeName: "type",
+			Pattern:       regexp.MustCompile("(?i)^" + regexp.QuoteMeta(b.armType) + "@"),
+		})
+	}
+	return conditions
+}
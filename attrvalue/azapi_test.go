@@ -0,0 +1,158 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestArmTypeValueRule(t *testing.T) {
+	replication := attrvalue.ForArmType(attrvalue.NewSimpleRule(attrvalue.AzapiResourceType, "body.sku.name", []string{"Standard_ZRS"}, "", true, "house_storage_sku"), "Microsoft.Storage/storageAccounts")
+	zonesWhenStandard := func() tflint.Rule {
+		condition, err := attrvalue.NewCondition("body.sku.name", cty.TupleVal([]cty.Value{cty.StringVal("Standard")}), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rule := attrvalue.NewSetRule(attrvalue.AzapiResourceType, "body.zones", [][]string{{"1", "2", "3"}}, "", "house_pip_zones")
+		return attrvalue.When(attrvalue.ForArmType(rule, "Microsoft.Network/publicIPAddresses"), *condition)
+	}()
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "property in the body",
+			rule: replication,
+			content: `
+	resource "azapi_resource" "this" {
+		type = "Microsoft.Storage/storageAccounts@2023-01-01"
+		body = {
+			sku = { name = "Standard_LRS" }
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    replication,
+					Message: "Standard_LRS is an invalid attribute value of `body.sku.name` - expecting (one of) [Standard_ZRS]",
+				},
+			},
+		},
+		{
+			name: "body encoded as JSON",
+			rule: replication,
+			content: `
+	resource "azapi_resource" "this" {
+		type = "Microsoft.Storage/storageAccounts@2023-01-01"
+		body = jsonencode({
+			sku = { name = "Standard_LRS" }
+		})
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    replication,
+					Message: "Standard_LRS is an invalid attribute value of `body.sku.name` - expecting (one of) [Standard_ZRS]",
+				},
+			},
+		},
+		{
+			name: "missing property",
+			rule: replication,
+			content: `
+	resource "azapi_resource" "this" {
+		type = "Microsoft.Storage/storageAccounts@2023-01-01"
+		body = {
+			kind = "StorageV2"
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    replication,
+					Message: "The attribute `body.sku.name` must be specified",
+				},
+			},
+		},
+		{
+			name: "ARM type in another case and API version from a variable",
+			rule: replication,
+			content: `
+	variable "api_version" {
+		type    = string
+		default = "2023-05-01"
+	}
+	resource "azapi_resource" "this" {
+		type = "microsoft.storage/storageaccounts@${var.api_version}"
+		body = {
+			sku = { name = "Standard_LRS" }
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    replication,
+					Message: "Standard_LRS is an invalid attribute value of `body.sku.name` - expecting (one of) [Standard_ZRS]",
+				},
+			},
+		},
+		{
+			name: "other ARM types",
+			rule: replication,
+			content: `
+	resource "azapi_resource" "container" {
+		type = "Microsoft.Storage/storageAccounts/blobServices/containers@2023-01-01"
+		body = {}
+	}
+	resource "azapi_resource" "lb" {
+		type = "Microsoft.Network/loadBalancers@2023-09-01"
+		body = {
+			sku = { name = "Basic" }
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "condition on a property in the body",
+			rule: zonesWhenStandard,
+			content: `
+	resource "azapi_resource" "standard" {
+		type = "Microsoft.Network/publicIPAddresses@2023-09-01"
+		body = {
+			sku   = { name = "Standard" }
+			zones = ["1"]
+		}
+	}
+	resource "azapi_resource" "basic" {
+		type = "Microsoft.Network/publicIPAddresses@2023-09-01"
+		body = {
+			sku   = { name = "Basic" }
+			zones = ["1"]
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    zonesWhenStandard,
+					Message: "\"[1]\" is an invalid attribute value of `body.zones` - expecting (one of) [[1 2 3]]",
+				},
+			},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
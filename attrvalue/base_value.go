@@ -2,23 +2,36 @@ package attrvalue
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/terraform"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 )
 
 var _ AttrValueRule = baseValue{}
 
 type baseValue struct {
-	resourceType    string // e.g. "azurerm_storage_account"
+	resourceType    string // e.g. "azurerm_storage_account", or the source of the called module for module calls
+	blockType       string // BlockTypeData or BlockTypeModule, empty for resources, see On.
 	nestedBlockType *string
-	attributeName   string // e.g. "account_replication_type"
+	blockPath       []string      // The nested block type split by dots, e.g. ["default_node_pool", "upgrade_settings"]. Empty for top-level attributes.
+	attributeName   string        // e.g. "account_replication_type", or a path inside a map or object attribute, e.g. `identity.type`
+	rootAttribute   string        // The name of the attribute in the resource, e.g. "identity"
+	valuePath       hcl.Traversal // The path to the checked value inside the attribute, e.g. `.type`. Empty for plain attribute names.
+	pathErr         error         // The error parsing the attribute name, returned when the rule is checked.
 	enabled         bool
 	link            string
 	severity        tflint.Severity
+	condition       *Condition // nil if the rule applies to all resources, see When.
+	armType         string     // The ARM type of the checked azapi resources, e.g. "Microsoft.Storage/storageAccounts", see ForArmType.
 }
 
+// GetNestedBlockType returns the type of the nested block the attribute is in, nil for top-level attributes.
+// Blocks nested at any depth are separated by dots, e.g. `default_node_pool.upgrade_settings`.
 func (b baseValue) GetNestedBlockType() *string {
 	return b.nestedBlockType
 }
@@ -31,10 +44,19 @@ func newBaseValue(
 	link string,
 	severity tflint.Severity,
 ) baseValue {
+	var blockPath []string
+	if nestedBlockType != nil {
+		blockPath = strings.Split(*nestedBlockType, ".")
+	}
+	rootAttribute, valuePath, err := parseAttributePath(attributeName)
 	return baseValue{
 		resourceType:    resourceType,
 		nestedBlockType: nestedBlockType,
+		blockPath:       blockPath,
 		attributeName:   attributeName,
+		rootAttribute:   rootAttribute,
+		valuePath:       valuePath,
+		pathErr:         err,
 		enabled:         enabled,
 		link:            link,
 		severity:        severity,
@@ -57,46 +79,111 @@ func (b baseValue) Severity() tflint.Severity {
 	return b.severity
 }
 
-func (b baseValue) attributeExistsWhereResourceIsSpecified(r tflint.Runner) (bool, *hclext.Block, error) {
-	_, resources, diags := fetchResourcesAndContext(b, r)
-	if diags.HasErrors() {
-		return false, nil, fmt.Errorf("could not get partial content: %s", diags)
+// checkAttributeExists reports the first resource instance where the attribute, or a nested block along the block path, is not specified.
+// For a path inside a map or object attribute, a known value without the path is reported too.
+// An attribute missing in a called module is reported at the module call, see moduleCallRunner.
+// It returns whether an issue was reported.
+func (b baseValue) checkAttributeExists(r tflint.Runner, rule tflint.Rule) (bool, error) {
+	if b.pathErr != nil {
+		return false, b.pathErr
 	}
-
-	if len(resources) == 0 {
-		return true, nil, nil
+	resources, diags := fetchResources(b, r)
+	if diags.HasErrors() {
+		return false, fmt.Errorf("could not get partial content: %s", diags)
 	}
 
+	message := fmt.Sprintf("The attribute `%s` must be specified", b.attributeName)
 	for _, resource := range resources {
-		if len(resource.Body.Attributes) == 0 && len(resource.Body.Blocks) == 0 {
-			return false, resource, nil
+		if missing := blockMissingAttribute(resource.Block, b.blockPath, b.rootAttribute); missing != nil {
+			return true, resource.runner(r, nil, b.attributePath()).EmitIssue(rule, message, missing.DefRange)
 		}
-		if len(resource.Body.Blocks) != 0 {
-			for _, block := range resource.Body.Blocks {
-				if len(block.Body.Attributes) == 0 {
-					return false, block, nil
-				}
+		if len(b.valuePath) == 0 {
+			continue
+		}
+		for _, block := range blocksAtPath(resource.Block, b.blockPath) {
+			attr := getAttrFromBlock(block, b.rootAttribute)
+			val, err := b.evaluate(resource.module.ctx, attr.Expr, cty.DynamicPseudoType)
+			if err != nil {
+				return false, err
+			}
+			if val.IsNull() {
+				return true, resource.runner(r, attr.Expr, b.attributePath()).EmitIssue(rule, message, attr.Range)
 			}
 		}
 	}
 
-	return true, nil, nil
+	return false, nil
 }
 
-func (b baseValue) checkAttributes(r tflint.Runner, ct cty.Type, c func(*hclext.Attribute, cty.Value) error) error {
-	ctx, attrs, diags := fetchAttrsAndContext(b, r)
+// checkAttributes evaluates the attribute in each resource instance and calls c with the value, or the value at the path inside it.
+// The runner given to c reports the issues found in the instance, see resourceInstance.runner.
+func (b baseValue) checkAttributes(r tflint.Runner, ct cty.Type, c func(tflint.Runner, *hclext.Attribute, cty.Value) error) error {
+	return b.eachAttribute(r, ct, func(resource *resourceInstance, attr *hclext.Attribute, val cty.Value) error {
+		return c(resource.runner(r, attr.Expr, b.attributePath()), attr, val)
+	})
+}
+
+// eachAttribute evaluates the attribute in each resource instance and calls c with the instance and the value, or the value at the path inside it.
+// The issues found at the origin of the values are reported once all the instances are checked, see originIssues.
+func (b baseValue) eachAttribute(r tflint.Runner, ct cty.Type, c func(*resourceInstance, *hclext.Attribute, cty.Value) error) error {
+	if b.pathErr != nil {
+		return b.pathErr
+	}
+	resources, diags := fetchResources(b, r)
 	if diags.HasErrors() {
 		return fmt.Errorf("could not get partial content: %s", diags)
 	}
-	for _, attr := range attrs {
-		val, diags := ctx.EvaluateExpr(attr.Expr, ct)
-		if diags.HasErrors() {
-			return fmt.Errorf("could not evaluate expression: %s", diags)
+	issues := &originIssues{}
+	for _, resource := range resources {
+		resource.issues = issues
+		for _, block := range blocksAtPath(resource.Block, b.blockPath) {
+			attr := getAttrFromBlock(block, b.rootAttribute)
+			if attr == nil {
+				continue
+			}
+			val, err := b.evaluate(resource.module.ctx, attr.Expr, ct)
+			if err != nil {
+				return err
+			}
+
+			if err := c(resource, attr, val); err != nil {
+				return err
+			}
 		}
+	}
+	return issues.emit()
+}
+
+// wrongTypeMessage returns the message of an issue reporting a value that cannot be converted to the type the rule checks, e.g. "a number".
+func wrongTypeMessage(attributeName string, val cty.Value, expected string) string {
+	got := fmt.Sprintf("a value of type %s", val.Type().FriendlyName())
+	if val.Type().IsPrimitiveType() {
+		got = formatValue(val)
+	}
+	return fmt.Sprintf("%s is an invalid attribute value of `%s` - expecting %s", got, attributeName, expected)
+}
 
-		if err := c(attr, val); err != nil {
-			return err
+// attributePath returns the path of the checked attribute in the resource, through the nested blocks, e.g. `site_config.ftps_state`.
+func (b baseValue) attributePath() string {
+	return strings.Join(append(append([]string{}, b.blockPath...), b.attributeName), ".")
+}
+
+// evaluate returns the value of the attribute expression, or the value at the path inside it, converted to the given type.
+func (b baseValue) evaluate(ctx *terraform.Evaluator, expr hcl.Expression, ct cty.Type) (cty.Value, error) {
+	if len(b.valuePath) == 0 {
+		val, diags := ctx.EvaluateExpr(expr, ct)
+		if diags.HasErrors() {
+			return cty.NilVal, fmt.Errorf("could not evaluate expression: %s", diags)
 		}
+		return val, nil
+	}
+	val, diags := ctx.EvaluateExpr(expr, cty.DynamicPseudoType)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("could not evaluate expression: %s", diags)
+	}
+	val, err := convert.Convert(valueAtPath(val, b.valuePath), ct)
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("could not convert the value of `%s`: %s", b.attributeName, err)
 	}
-	return nil
+	return val, nil
 }
@@ -0,0 +1,127 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestBlockTypeValueRule(t *testing.T) {
+	const lbSource = "Azure/avm-res-network-loadbalancer/azurerm"
+	secretVersion := attrvalue.On(attrvalue.NewUnknownValueRule("azurerm_key_vault_secret", "version", "", "house_secret_version"), attrvalue.BlockTypeData)
+	keyVaultResourceGroup := attrvalue.On(attrvalue.NewMustExistRule("azurerm_key_vault", "resource_group_name", "", "house_key_vault_resource_group"), attrvalue.BlockTypeData)
+	lbSku := attrvalue.On(attrvalue.NewSimpleRule(lbSource, "sku", []string{"Standard"}, "", false, "house_lb_module_sku"), attrvalue.BlockTypeModule)
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "data source",
+			rule: secretVersion,
+			content: `
+	data "azurerm_key_vault_secret" "password" {
+		name    = "password"
+		version = "3f5c"
+	}
+	resource "azurerm_key_vault_secret" "password" {
+		name    = "password"
+		version = "3f5c"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    secretVersion,
+					Message: "invalid attribute value of `version` - expecting unknown",
+				},
+			},
+		},
+		{
+			name: "missing data source attribute",
+			rule: keyVaultResourceGroup,
+			content: `
+	data "azurerm_key_vault" "this" {
+		name = "kv"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    keyVaultResourceGroup,
+					Message: "The attribute `resource_group_name` must be specified",
+				},
+			},
+		},
+		{
+			name: "module call",
+			rule: lbSku,
+			content: `
+	module "lb" {
+		source  = "Azure/avm-res-network-loadbalancer/azurerm"
+		version = "0.2.0"
+		sku     = "Basic"
+	}
+	module "other" {
+		source = "Azure/avm-res-network-publicipaddress/azurerm"
+		sku    = "Basic"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    lbSku,
+					Message: "Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+				},
+			},
+		},
+		{
+			name: "module call argument from a variable",
+			rule: lbSku,
+			content: `
+	variable "lb_sku" {
+		type    = string
+		default = "Basic"
+	}
+	module "lb" {
+		source = "Azure/avm-res-network-loadbalancer/azurerm"
+		sku    = var.lb_sku
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    lbSku,
+					Message: "`var.lb_sku (default) -> module.lb.sku`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+				},
+			},
+		},
+		{
+			name: "module call with for_each",
+			rule: lbSku,
+			content: `
+	module "lb" {
+		for_each = toset(["Basic", "Standard"])
+		source   = "Azure/avm-res-network-loadbalancer/azurerm"
+		sku      = each.key
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    lbSku,
+					Message: "`module.lb[\"Basic\"]`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+				},
+			},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package attrvalue
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/terraform/tfhcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// branch is an expression an attribute value can come from, with the conditions leading to it,
+// e.g. `"Basic"` when `var.premium` is false for `var.premium ? "Premium" : "Basic"`.
+type branch struct {
+	expr       hcl.Expression
+	conditions []string // e.g. ["`var.premium` is false"]
+}
+
+// branches returns the reachable branches of a conditional, coalesce() or try() expression of the resource instance,
+// at any depth and through local values. An expression without branches is its own single branch.
+// A branch is unreachable when the value of the condition leading away from it is known for the instance
+// and does not depend on a variable of the root module, which the callers of the module can set to anything.
+// Splitting the expression loses the count and for_each binding of the instance, so the branches are evaluated with evaluateExpr.
+func (i *resourceInstance) branches(expr hcl.Expression) []*branch {
+	switch e := hcl.UnwrapExpression(expr).(type) {
+	case *hclsyntax.ParenthesesExpr:
+		return i.branches(e.Expression)
+	case *hclsyntax.TemplateWrapExpr:
+		return i.branches(e.Wrapped)
+	case *hclsyntax.ScopeTraversalExpr:
+		if e.Traversal.RootName() == "local" && len(e.Traversal) == 2 {
+			if name, ok := e.Traversal[1].(hcl.TraverseAttr); ok {
+				if local, ok := i.module.config.Module.Locals[name.Name]; ok {
+					return i.branches(local.Expr)
+				}
+			}
+		}
+	case *hclsyntax.ConditionalExpr:
+		condition := i.module.source(e.Condition)
+		var branches []*branch
+		val, diags := i.evaluateExpr(e.Condition, cty.Bool)
+		fixed := !diags.HasErrors() && val.IsKnown() && !val.IsNull() && !i.dependsOnInputs(e.Condition)
+		if !fixed || val.True() {
+			branches = append(branches, i.branchesWhen(e.TrueResult, fmt.Sprintf("`%s` is true", condition))...)
+		}
+		if !fixed || val.False() {
+			branches = append(branches, i.branchesWhen(e.FalseResult, fmt.Sprintf("`%s` is false", condition))...)
+		}
+		return branches
+	case *hclsyntax.FunctionCallExpr:
+		switch e.Name {
+		case "coalesce":
+			return i.argumentBranches(e.Args, "is not null", "is null", func(val cty.Value, diags hcl.Diagnostics) bool {
+				return !diags.HasErrors() && val.IsKnown() && !val.IsNull() && !val.RawEquals(cty.StringVal(""))
+			})
+		case "try":
+			return i.argumentBranches(e.Args, "succeeds", "fails", func(val cty.Value, diags hcl.Diagnostics) bool {
+				return !diags.HasErrors() && val.IsWhollyKnown()
+			})
+		}
+	}
+	return []*branch{{expr: expr}}
+}
+
+// argumentBranches returns the branches of the arguments of a function returning the first of its arguments that is taken,
+// such as coalesce() or try(). An argument is reachable if none of the previous arguments is always taken, see branches.
+func (i *resourceInstance) argumentBranches(args []hclsyntax.Expression, taken, skipped string, isTaken func(cty.Value, hcl.Diagnostics) bool) []*branch {
+	var branches []*branch
+	var previous []string
+	for n, arg := range args {
+		var conditions []string
+		if n == 0 && len(args) > 1 {
+			conditions = []string{fmt.Sprintf("`%s` %s", i.module.source(arg), taken)}
+		}
+		for _, p := range previous {
+			conditions = append(conditions, fmt.Sprintf("`%s` %s", p, skipped))
+		}
+		branches = append(branches, i.branchesWhen(arg, conditions...)...)
+
+		val, diags := i.evaluateExpr(arg, cty.DynamicPseudoType)
+		if isTaken(val, diags) && !i.dependsOnInputs(arg) {
+			break
+		}
+		previous = append(previous, i.module.source(arg))
+	}
+	return branches
+}
+
+// branchesWhen returns the branches of the expression, reached when the conditions hold.
+func (i *resourceInstance) branchesWhen(expr hcl.Expression, conditions ...string) []*branch {
+	branches := i.branches(expr)
+	for _, b := range branches {
+		b.conditions = append(append([]string{}, conditions...), b.conditions...)
+	}
+	return branches
+}
+
+// evaluateExpr evaluates an expression of the resource instance with its count index or for_each key and value,
+// which the evaluator of the module leaves unknown.
+func (i *resourceInstance) evaluateExpr(expr hcl.Expression, ct cty.Type) (cty.Value, hcl.Diagnostics) {
+	return i.module.ctx.EvaluateExpr(i.bind(expr), ct)
+}
+
+// bind returns the expression evaluated with the count index or for_each key and value of the resource instance.
+func (i *resourceInstance) bind(expr hcl.Expression) hcl.Expression {
+	switch {
+	case i.each.Type() != cty.NilType:
+		return &iterationExpr{Expression: expr, eval: tfhcl.MakeForEachIteration(i.key, i.each).EvalContext}
+	case i.key.Type() != cty.NilType:
+		return &iterationExpr{Expression: expr, eval: tfhcl.MakeCountIteration(i.key).EvalContext}
+	}
+	return expr
+}
+
+// iterationExpr is an expression evaluated in a child of the evaluation context defining `count` or `each`.
+type iterationExpr struct {
+	hcl.Expression
+	eval func(*hcl.EvalContext) *hcl.EvalContext
+}
+
+func (e *iterationExpr) Value(ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	return e.Expression.Value(e.eval(ctx))
+}
+
+// dependsOnInputs returns whether the expression of the resource instance refers to a variable of the root module,
+// directly, through local values, or through the count or for_each of the resource.
+func (i *resourceInstance) dependsOnInputs(expr hcl.Expression) bool {
+	if i.module.dependsOnInputs(expr) {
+		return true
+	}
+	for _, traversal := range expr.Variables() {
+		meta := traversal.RootName()
+		if meta == "each" {
+			meta = "for_each"
+		}
+		if meta != "count" && meta != "for_each" {
+			continue
+		}
+		if attr, ok := i.Body.Attributes[meta]; ok && i.module.dependsOnInputs(attr.Expr) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependsOnInputs returns whether the expression refers to a variable of the root module, directly or through local values.
+// The variables of a called module are bound to the arguments of its module call.
+func (m *moduleInstance) dependsOnInputs(expr hcl.Expression) bool {
+	for _, traversal := range expr.Variables() {
+		switch traversal.RootName() {
+		case "var":
+			if m.caller == nil {
+				return true
+			}
+		case "local":
+			if len(traversal) < 2 {
+				continue
+			}
+			name, ok := traversal[1].(hcl.TraverseAttr)
+			if !ok {
+				continue
+			}
+			if local, ok := m.config.Module.Locals[name.Name]; ok && m.dependsOnInputs(local.Expr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// source returns the source code of the expression, e.g. `var.premium`.
+func (m *moduleInstance) source(expr hcl.Expression) string {
+	rng := expr.Range()
+	src, ok := m.config.Module.Sources[rng.Filename]
+	if !ok || rng.End.Byte > len(src) {
+		return "expression"
+	}
+	return string(rng.SliceBytes(src))
+}
+
+var _ tflint.Runner = new(branchRunner)
+
+// branchRunner reports the issues found in a branch of an attribute at the expression of the branch,
+// and prefixes their messages with the attribute and the conditions leading to the branch, e.g. "`azurerm_lb.this.sku` when `var.premium` is false".
+type branchRunner struct {
+	tflint.Runner
+	rng    hcl.Range
+	prefix string
+}
+
+func (r *branchRunner) EmitIssue(rule tflint.Rule, message string, _ hcl.Range) error {
+	return r.Runner.EmitIssue(rule, fmt.Sprintf("%s: %s", r.prefix, message), r.rng)
+}
+
+// runner returns a runner reporting the issues found in the branch of the attribute of the resource instance.
+func (b *branch) runner(runner tflint.Runner, resource *resourceInstance, attribute string) tflint.Runner {
+	prefix := fmt.Sprintf("`%s.%s`", resource.address(), attribute)
+	if len(b.conditions) > 0 {
+		prefix += " when " + strings.Join(b.conditions, " and ")
+	}
+	return &branchRunner{
+		Runner: runner,
+		rng:    b.expr.Range(),
+		prefix: prefix,
+	}
+}
@@ -0,0 +1,227 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func TestBranchValueRule(t *testing.T) {
+	type issue struct {
+		message string
+		start   hcl.Pos
+	}
+	testCases := []struct {
+		name     string
+		content  string
+		expected []issue
+	}{
+		{
+			name: "conditional on a variable",
+			content: `variable "premium" {
+  type    = bool
+  default = true
+}
+resource "foo" "this" {
+  sku = var.premium ? "Premium" : "Basic"
+}`,
+			expected: []issue{
+				{
+					message: "`foo.this.sku` when `var.premium` is false: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard Premium]",
+					start:   hcl.Pos{Line: 6, Column: 35},
+				},
+			},
+		},
+		{
+			name: "compliant branches",
+			content: `variable "premium" {
+  type = bool
+}
+resource "foo" "this" {
+  sku = var.premium ? "Premium" : "Standard"
+}`,
+			expected: nil,
+		},
+		{
+			name: "nested conditionals in a local value",
+			content: `variable "premium" {
+  type = bool
+}
+variable "environment" {
+  type = string
+}
+locals {
+  sku = var.premium ? "Premium" : (var.environment == "prod" ? "Standard" : "Basic")
+}
+resource "foo" "this" {
+  sku = local.sku
+}`,
+			expected: []issue{
+				{
+					message: "`foo.this.sku` when `var.premium` is false and `var.environment == \"prod\"` is false: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard Premium]",
+					start:   hcl.Pos{Line: 8, Column: 77},
+				},
+			},
+		},
+		{
+			name: "unreachable branch",
+			content: `locals {
+  premium = true
+}
+resource "foo" "this" {
+  sku = local.premium ? "Premium" : "Basic"
+}`,
+			expected: nil,
+		},
+		{
+			name: "condition depending on a variable through a local value",
+			content: `variable "tier" {
+  type    = string
+  default = "premium"
+}
+locals {
+  premium = var.tier == "premium"
+}
+resource "foo" "this" {
+  sku = local.premium ? "Premium" : "Basic"
+}`,
+			expected: []issue{
+				{
+					message: "`foo.this.sku` when `local.premium` is false: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard Premium]",
+					start:   hcl.Pos{Line: 9, Column: 37},
+				},
+			},
+		},
+		{
+			name: "coalesce",
+			content: `variable "sku" {
+  type    = string
+  default = null
+}
+resource "foo" "this" {
+  sku = coalesce(var.sku, "Basic")
+}`,
+			expected: []issue{
+				{
+					message: "`foo.this.sku` when `var.sku` is null: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard Premium]",
+					start:   hcl.Pos{Line: 6, Column: 27},
+				},
+			},
+		},
+		{
+			name: "coalesce of a known value",
+			content: `variable "sku" {
+  type = string
+}
+resource "foo" "this" {
+  sku = coalesce("Standard", var.sku, "Basic")
+}`,
+			expected: nil,
+		},
+		{
+			name: "try",
+			content: `variable "settings" {
+  type    = any
+  default = {}
+}
+resource "foo" "this" {
+  sku = try(var.settings.sku, "Basic")
+}`,
+			expected: []issue{
+				{
+					message: "`foo.this.sku` when `var.settings.sku` fails: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard Premium]",
+					start:   hcl.Pos{Line: 6, Column: 31},
+				},
+			},
+		},
+		{
+			name: "non-compliant first argument",
+			content: `variable "sku" {
+  type    = string
+  default = "Basic"
+}
+resource "foo" "this" {
+  sku = coalesce(var.sku, "Standard")
+}`,
+			expected: []issue{
+				{
+					message: "`foo.this.sku` when `var.sku` is not null: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard Premium]",
+					start:   hcl.Pos{Line: 6, Column: 18},
+				},
+			},
+		},
+		{
+			name: "for_each value in coalesce",
+			content: `variable "pips" {
+  type = map(object({
+    sku = optional(string)
+  }))
+  default = {
+    pip1 = {
+      sku = "Basic"
+    }
+  }
+}
+resource "foo" "this" {
+  for_each = var.pips
+  sku      = coalesce(each.value.sku, "Standard")
+}`,
+			expected: []issue{
+				{
+					message: "`foo.this[\"pip1\"].sku` when `each.value.sku` is not null: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard Premium]",
+					start:   hcl.Pos{Line: 13, Column: 23},
+				},
+			},
+		},
+		{
+			name: "condition on the for_each value",
+			content: `locals {
+  premium = {
+    pip1 = true
+    pip2 = true
+  }
+}
+resource "foo" "this" {
+  for_each = local.premium
+  sku      = each.value ? "Premium" : "Basic"
+}`,
+			expected: nil,
+		},
+		{
+			name: "condition on the count index",
+			content: `resource "foo" "this" {
+  count = 2
+  sku   = count.index == 0 ? "Premium" : "Basic"
+}`,
+			expected: []issue{
+				{
+					message: "`foo.this[1]`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard Premium]",
+					start:   hcl.Pos{Line: 3, Column: 3},
+				},
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			rule := attrvalue.NewSimpleRule("foo", "sku", []string{"Standard", "Premium"}, "", false, "")
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var issues []issue
+			for _, i := range runner.Issues {
+				issues = append(issues, issue{message: i.Message, start: hcl.Pos{Line: i.Range.Start.Line, Column: i.Range.Start.Column}})
+			}
+			assert.Equal(t, tc.expected, issues)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package attrvalue
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint/terraform"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// Condition limits an attribute value rule to the resources where another attribute has one of the given values,
+// e.g. zones only matter when the `sku` of a public IP is `Standard`.
+// The condition attribute is a top-level attribute of the same resource, also when the checked attribute is in a nested block.
+// It can be followed by a path to a value inside a map or object attribute, e.g. `body.sku.name`.
+// Resources where the condition attribute is not specified, null or unknown are not checked.
+type Condition struct {
+	AttributeName string         // e.g. "sku"
+	Values        []cty.Value    // The attribute must equal one of the values, e.g. cty.StringVal("Standard").
+	Pattern       *regexp.Regexp // Or, when set, the attribute must match the pattern, e.g. `^GP_`.
+}
+
+// NewCondition returns the condition on the attribute, from a list of values or a pattern, as declared in a config file.
+// A resource whose condition attribute is not specified, null or unknown does not match the condition, so the rule
+// skips it without reporting anything, also in strict mode.
+func NewCondition(attributeName string, values cty.Value, pattern string) (*Condition, error) {
+	if attributeName == "" {
+		return nil, fmt.Errorf("a condition needs an attribute")
+	}
+	if _, _, err := parseAttributePath(attributeName); err != nil {
+		return nil, err
+	}
+	hasValues := values != cty.NilVal && !values.IsNull()
+	if hasValues == (pattern != "") {
+		return nil, fmt.Errorf("a condition on `%s` needs either values or a pattern", attributeName)
+	}
+	c := &Condition{AttributeName: attributeName}
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern in the condition on `%s`: %w", attributeName, err)
+		}
+		c.Pattern = re
+		return c, nil
+	}
+	if !values.IsWhollyKnown() || !values.CanIterateElements() || values.LengthInt() == 0 {
+		return nil, fmt.Errorf("the values of the condition on `%s` must be a non-empty list", attributeName)
+	}
+	for _, v := range values.AsValueSlice() {
+		if !v.Type().IsPrimitiveType() {
+			return nil, fmt.Errorf("the values of the condition on `%s` must be strings, numbers or bools", attributeName)
+		}
+		c.Values = append(c.Values, v)
+	}
+	return c, nil
+}
+
+// Conditional is implemented by the attribute value rules, to describe the condition they are limited to.
+type Conditional interface {
+	GetCondition() *Condition
+}
+
+// conditionSetter is implemented by the attribute value rules through baseValue.
+type conditionSetter interface {
+	setCondition(*Condition)
+}
+
+// When limits the rule to the resources that meet the condition and returns it.
+func When[R conditionSetter](rule R, condition Condition) R {
+	rule.setCondition(&condition)
+	return rule
+}
+
+func (b *baseValue) setCondition(c *Condition) {
+	b.condition = c
+}
+
+// blockTypeSetter is implemented by the attribute value rules through baseValue.
+type blockTypeSetter interface {
+	setBlockType(string)
+}
+
+// On makes the rule check the blocks of the given kind, BlockTypeData or BlockTypeModule, instead of resources and returns it.
+// The resource type of the rule is then the type of the data source, or the source of the called module,
+// e.g. `Azure/avm-res-network-loadbalancer/azurerm`.
+func On[R blockTypeSetter](rule R, blockType string) R {
+	rule.setBlockType(blockType)
+	return rule
+}
+
+func (b *baseValue) setBlockType(blockType string) {
+	b.blockType = blockType
+}
+
+// GetBlockType returns the kind of block the rule checks, BlockTypeResource unless set with On.
+func (b baseValue) GetBlockType() string {
+	if b.blockType == "" {
+		return BlockTypeResource
+	}
+	return b.blockType
+}
+
+// GetCondition returns the condition the rule is limited to, nil if it applies to all resources.
+func (b baseValue) GetCondition() *Condition {
+	return b.condition
+}
+
+// String describes the condition, e.g. "`sku` is one of "Standard"".
+func (c Condition) String() string {
+	if c.Pattern != nil {
+		return fmt.Sprintf("`%s` matches `%s`", c.AttributeName, c.Pattern)
+	}
+	values := make([]string, 0, len(c.Values))
+	for _, v := range c.Values {
+		values = append(values, formatValue(v))
+	}
+	return fmt.Sprintf("`%s` is one of %s", c.AttributeName, strings.Join(values, ", "))
+}
+
+// matches reports whether the resource meets the condition.
+func (c *Condition) matches(resource *hclext.Block, ctx *terraform.Evaluator) (bool, hcl.Diagnostics) {
+	if c == nil {
+		return true, nil
+	}
+	attr, ok := resource.Body.Attributes[c.rootAttribute()]
+	if !ok {
+		return false, nil
+	}
+	val, diags := ctx.EvaluateExpr(attr.Expr, cty.DynamicPseudoType)
+	if diags.HasErrors() {
+		return false, diags
+	}
+	if _, path, err := parseAttributePath(c.AttributeName); err == nil {
+		val = valueAtPath(val, path)
+	}
+	if val.IsNull() || !val.IsWhollyKnown() {
+		return false, nil
+	}
+	if c.Pattern != nil {
+		str, err := convert.Convert(val, cty.String)
+		return err == nil && c.Pattern.MatchString(str.AsString()), nil
+	}
+	for _, v := range c.Values {
+		converted, err := convert.Convert(val, v.Type())
+		if err == nil && converted.Equals(v).True() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rootAttribute returns the name of the condition attribute in the resource, e.g. `body` for `body.sku.name`.
+func (c *Condition) rootAttribute() string {
+	root, _, err := parseAttributePath(c.AttributeName)
+	if err != nil {
+		return c.AttributeName
+	}
+	return root
+}
+
+// formatValue formats a primitive value the way it is written in HCL.
+func formatValue(v cty.Value) string {
+	if v.Type() == cty.String {
+		return fmt.Sprintf("%q", v.AsString())
+	}
+	if str, err := convert.Convert(v, cty.String); err == nil && str.IsKnown() && !str.IsNull() {
+		return str.AsString()
+	}
+	return v.GoString()
+}
@@ -0,0 +1,181 @@
+package attrvalue_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestConditionalRule(t *testing.T) {
+	standardSku := attrvalue.Condition{AttributeName: "sku", Values: []cty.Value{cty.StringVal("Standard")}}
+	zonesRule := func() tflint.Rule {
+		return attrvalue.When(attrvalue.NewSetRule("foo", "zones", [][]string{{"1", "2", "3"}}, "", ""), standardSku)
+	}
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "condition met and value correct",
+			rule: zonesRule(),
+			content: `
+	resource "foo" "example" {
+		sku   = "Standard"
+		zones = ["1", "2", "3"]
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "condition met from variable default and value incorrect",
+			rule: zonesRule(),
+			content: `
+	variable "sku" {
+		type    = string
+		default = "Standard"
+	}
+	resource "foo" "example" {
+		sku   = var.sku
+		zones = ["1"]
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    zonesRule(),
+					Message: "\"[1]\" is an invalid attribute value of `zones` - expecting (one of) [[1 2 3]]",
+				},
+			},
+		},
+		{
+			name: "condition not met",
+			rule: zonesRule(),
+			content: `
+	resource "foo" "example" {
+		sku   = "Basic"
+		zones = ["1"]
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "condition attribute not specified, null or unknown",
+			rule: zonesRule(),
+			content: `
+	variable "sku" {
+		type = string
+	}
+	resource "foo" "example" {
+		zones = ["1"]
+	}
+	resource "foo" "example2" {
+		sku   = var.sku
+		zones = ["1"]
+	}
+	resource "foo" "example3" {
+		sku   = null
+		zones = ["1"]
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "condition evaluated per resource",
+			rule: attrvalue.When(attrvalue.NewSimpleRule("foo", "bar", []string{"biz"}, "", false, ""), standardSku),
+			content: `
+	resource "foo" "basic" {
+		sku = "Basic"
+		bar = "baz"
+	}
+	resource "foo" "standard" {
+		sku = "Standard"
+		bar = "fiz"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.When(attrvalue.NewSimpleRule("foo", "bar", []string{"biz"}, "", false, ""), standardSku),
+					Message: "fiz is an invalid attribute value of `bar` - expecting (one of) [biz]",
+				},
+			},
+		},
+		{
+			name: "pattern condition on a nested block rule",
+			rule: attrvalue.When(
+				attrvalue.NewSimpleNestedBlockRule("foo", "high_availability", "mode", []string{"ZoneRedundant"}, "", true, ""),
+				attrvalue.Condition{AttributeName: "sku_name", Pattern: regexp.MustCompile(`^(GP|MO)_`)},
+			),
+			content: `
+	resource "foo" "burstable" {
+		sku_name = "B_Standard_B1ms"
+	}
+	resource "foo" "general_purpose" {
+		sku_name = "GP_Standard_D4s_v3"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleNestedBlockRule("foo", "high_availability", "mode", []string{"ZoneRedundant"}, "", true, ""),
+					Message: "The attribute `mode` must be specified",
+				},
+			},
+		},
+		{
+			name: "must exist with the condition attribute specified",
+			rule: attrvalue.When(attrvalue.NewMustExistRule("foo", "zone_balancing_enabled", "", ""), standardSku),
+			content: `
+	resource "foo" "example" {
+		sku = "Standard"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewMustExistRule("foo", "zone_balancing_enabled", "", ""),
+					Message: "The attribute `zone_balancing_enabled` must be specified",
+				},
+			},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
+
+func TestNewCondition(t *testing.T) {
+	c, err := attrvalue.NewCondition("sku", cty.TupleVal([]cty.Value{cty.StringVal("Standard")}), "")
+	require.NoError(t, err)
+	assert.Equal(t, "`sku` is one of \"Standard\"", c.String())
+
+	c, err = attrvalue.NewCondition("sku_name", cty.NilVal, "^P[0-9]+m?v3$")
+	require.NoError(t, err)
+	assert.Equal(t, "`sku_name` matches `^P[0-9]+m?v3$`", c.String())
+
+	for desc, tc := range map[string]struct {
+		attribute string
+		values    cty.Value
+		pattern   string
+	}{
+		"no attribute":         {values: cty.TupleVal([]cty.Value{cty.StringVal("Standard")})},
+		"neither":              {attribute: "sku"},
+		"both":                 {attribute: "sku", values: cty.TupleVal([]cty.Value{cty.StringVal("Standard")}), pattern: "^Standard$"},
+		"empty values":         {attribute: "sku", values: cty.EmptyTupleVal},
+		"non-primitive values": {attribute: "sku", values: cty.TupleVal([]cty.Value{cty.EmptyObjectVal})},
+		"invalid pattern":      {attribute: "sku", pattern: "P("},
+	} {
+		_, err := attrvalue.NewCondition(tc.attribute, tc.values, tc.pattern)
+		assert.Errorf(t, err, desc)
+	}
+}
@@ -0,0 +1,88 @@
+package attrvalue
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// expandUnknownDynamicBlocks makes the dynamic blocks of the modules of the configuration with an unknown for_each expand into a single block,
+// whose content is evaluated with an unknown iterator.
+// Dynamic blocks with a known for_each are expanded as usual when the module content is read with an evaluator,
+// but those with an unknown for_each would generate no block at all,
+// hiding the attributes that do not depend on the iterator and making the nested block look missing.
+func expandUnknownDynamicBlocks(config *terraform.Config) {
+	for _, file := range config.Module.Files {
+		file.Body = &unknownIteratorBody{Body: file.Body}
+	}
+	for _, child := range config.Children {
+		expandUnknownDynamicBlocks(child)
+	}
+}
+
+var _ hcl.Body = new(unknownIteratorBody)
+
+// unknownIteratorBody wraps a body so that the for_each of the dynamic blocks it contains, at any depth,
+// is replaced with a single unknown element when it is unknown.
+type unknownIteratorBody struct {
+	hcl.Body
+	dynamic bool // whether the body is the body of a dynamic block
+}
+
+func (b *unknownIteratorBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	content, diags := b.Body.Content(schema)
+	return b.wrapContent(content), diags
+}
+
+func (b *unknownIteratorBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	content, remain, diags := b.Body.PartialContent(schema)
+	return b.wrapContent(content), &unknownIteratorBody{Body: remain, dynamic: b.dynamic}, diags
+}
+
+func (b *unknownIteratorBody) wrapContent(content *hcl.BodyContent) *hcl.BodyContent {
+	if content == nil {
+		return nil
+	}
+	wrapped := *content
+	if forEach, ok := content.Attributes["for_each"]; ok && b.dynamic {
+		wrapped.Attributes = make(hcl.Attributes, len(content.Attributes))
+		for name, attr := range content.Attributes {
+			wrapped.Attributes[name] = attr
+		}
+		attr := *forEach
+		attr.Expr = &unknownForEachExpr{Expression: forEach.Expr}
+		wrapped.Attributes["for_each"] = &attr
+	}
+	wrapped.Blocks = make(hcl.Blocks, 0, len(content.Blocks))
+	for _, block := range content.Blocks {
+		b := *block
+		b.Body = &unknownIteratorBody{Body: block.Body, dynamic: block.Type == "dynamic"}
+		wrapped.Blocks = append(wrapped.Blocks, &b)
+	}
+	return &wrapped
+}
+
+var _ hcl.Expression = new(unknownForEachExpr)
+
+// unknownForEachExpr is the for_each expression of a dynamic block.
+// An unknown value is replaced with a set of a single unknown element, so that both the key and the value of the iterator are unknown.
+type unknownForEachExpr struct {
+	hcl.Expression
+}
+
+func (e *unknownForEachExpr) Value(ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	val, diags := e.Expression.Value(ctx)
+	if diags.HasErrors() || val.IsKnown() {
+		return val, diags
+	}
+	elementType := cty.DynamicPseudoType
+	if val.Type().IsCollectionType() {
+		elementType = val.Type().ElementType()
+	}
+	return cty.SetVal([]cty.Value{cty.UnknownVal(elementType)}), diags
+}
+
+// UnwrapExpression returns the original for_each expression, see hcl.UnwrapExpression.
+func (e *unknownForEachExpr) UnwrapExpression() hcl.Expression {
+	return e.Expression
+}
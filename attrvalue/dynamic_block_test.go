@@ -0,0 +1,216 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestDynamicBlockValueRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "known for_each correct",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", "backup", "type", []string{"Continuous"}, "", true, ""),
+			content: `
+	variable "backup" {
+		type    = object({ type = string })
+		default = { type = "Continuous" }
+	}
+	resource "foo" "example" {
+		dynamic "backup" {
+			for_each = [var.backup]
+			content {
+				type = backup.value.type
+			}
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "known for_each incorrect",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", "backup", "type", []string{"Continuous"}, "", false, ""),
+			content: `
+	variable "backup" {
+		type    = object({ type = string })
+		default = { type = "Periodic" }
+	}
+	resource "foo" "example" {
+		dynamic "backup" {
+			for_each = [var.backup]
+			content {
+				type = backup.value.type
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleNestedBlockRule("foo", "backup", "type", []string{"Continuous"}, "", false, ""),
+					Message: "Periodic is an invalid attribute value of `type` - expecting (one of) [Continuous]",
+				},
+			},
+		},
+		{
+			name: "known empty for_each generates no block",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", "backup", "type", []string{"Continuous"}, "", true, ""),
+			content: `
+	variable "backup" {
+		type    = object({ type = string })
+		default = null
+	}
+	resource "foo" "example" {
+		dynamic "backup" {
+			for_each = var.backup == null ? [] : [var.backup]
+			content {
+				type = backup.value.type
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleNestedBlockRule("foo", "backup", "type", []string{"Continuous"}, "", true, ""),
+					Message: "The attribute `type` must be specified",
+				},
+			},
+		},
+		{
+			name: "unknown for_each with a value from the iterator",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", "backup", "type", []string{"Continuous"}, "", true, ""),
+			content: `
+	variable "backup" {
+		type = object({ type = string })
+	}
+	resource "foo" "example" {
+		dynamic "backup" {
+			for_each = [var.backup]
+			content {
+				type = backup.value.type
+			}
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "unknown for_each with a literal value",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", "backup", "type", []string{"Continuous"}, "", true, ""),
+			content: `
+	variable "backups" {
+		type = map(string)
+	}
+	resource "foo" "example" {
+		dynamic "backup" {
+			for_each = var.backups
+			content {
+				type = "Periodic"
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleNestedBlockRule("foo", "backup", "type", []string{"Continuous"}, "", true, ""),
+					Message: "Periodic is an invalid attribute value of `type` - expecting (one of) [Continuous]",
+				},
+			},
+		},
+		{
+			name: "unknown for_each with the attribute missing",
+			rule: attrvalue.NewMustExistNestedBlockRule("foo", "backup", "type", "", ""),
+			content: `
+	variable "backups" {
+		type = list(string)
+	}
+	resource "foo" "example" {
+		dynamic "backup" {
+			for_each = var.backups
+			iterator = b
+			content {
+				interval_in_minutes = b.value
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewMustExistNestedBlockRule("foo", "backup", "type", "", ""),
+					Message: "The attribute `type` must be specified",
+				},
+			},
+		},
+		{
+			name: "nested dynamic blocks",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", "site_config.ip_restriction", "action", []string{"Allow"}, "", true, ""),
+			content: `
+	variable "site_config" {
+		type = object({ ip_restrictions = list(string) })
+	}
+	resource "foo" "example" {
+		dynamic "site_config" {
+			for_each = [var.site_config]
+			content {
+				dynamic "ip_restriction" {
+					for_each = site_config.value.ip_restrictions
+					content {
+						ip_address = ip_restriction.value
+						action     = "Deny"
+					}
+				}
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleNestedBlockRule("foo", "site_config.ip_restriction", "action", []string{"Allow"}, "", true, ""),
+					Message: "Deny is an invalid attribute value of `action` - expecting (one of) [Allow]",
+				},
+			},
+		},
+		{
+			name: "dynamic block mixed with a literal block",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", "ip_restriction", "action", []string{"Allow"}, "", false, ""),
+			content: `
+	variable "ip_restrictions" {
+		type    = list(string)
+		default = ["10.0.0.0/24"]
+	}
+	resource "foo" "example" {
+		ip_restriction {
+			action = "Allow"
+		}
+		dynamic "ip_restriction" {
+			for_each = var.ip_restrictions
+			content {
+				ip_address = ip_restriction.value
+				action     = "Deny"
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleNestedBlockRule("foo", "ip_restriction", "action", []string{"Allow"}, "", false, ""),
+					Message: "Deny is an invalid attribute value of `action` - expecting (one of) [Allow]",
+				},
+			},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
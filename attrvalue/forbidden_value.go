@@ -0,0 +1,110 @@
+package attrvalue
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// ForbiddenRule checks whether an attribute value is one of the forbidden values, the opposite of SimpleRule.
+// It can be used to check string, number, and bool attributes.
+type ForbiddenRule[T any] struct {
+	tflint.DefaultRule // Embed the default rule to reuse its implementation
+	baseValue
+	forbiddenValues []T    // e.g. []string{"Basic"}
+	recommendation  string // e.g. "Standard", empty if there is no recommended alternative
+	mustExist       bool
+	ruleName        string
+}
+
+var _ tflint.Rule = (*ForbiddenRule[any])(nil)
+var _ ForbiddenValuesRule = (*ForbiddenRule[any])(nil)
+
+// NewForbiddenRule returns a new rule with the given resource type, attribute name, forbidden values, and recommended alternative.
+func NewForbiddenRule[T any](resourceType, attributeName string, forbiddenValues []T, recommendation, link string, mustExist bool, ruleName string) *ForbiddenRule[T] {
+	return &ForbiddenRule[T]{
+		baseValue:       newBaseValue(resourceType, nil, attributeName, true, link, tflint.ERROR),
+		forbiddenValues: forbiddenValues,
+		recommendation:  recommendation,
+		mustExist:       mustExist,
+		ruleName:        ruleName,
+	}
+}
+
+// NewForbiddenNestedBlockRule returns a new rule with the given resource type, nested block type, attribute name, forbidden values, and recommended alternative.
+func NewForbiddenNestedBlockRule[T any](resourceType, nestedBlockType, attributeName string, forbiddenValues []T, recommendation, link string, mustExist bool, ruleName string) *ForbiddenRule[T] {
+	return &ForbiddenRule[T]{
+		baseValue:       newBaseValue(resourceType, &nestedBlockType, attributeName, true, link, tflint.ERROR),
+		forbiddenValues: forbiddenValues,
+		recommendation:  recommendation,
+		mustExist:       mustExist,
+		ruleName:        ruleName,
+	}
+}
+
+func (r *ForbiddenRule[T]) Link() string {
+	return r.link
+}
+
+// GetForbiddenValues returns the values the attribute must not have.
+func (r *ForbiddenRule[T]) GetForbiddenValues() []any {
+	values := make([]any, 0, len(r.forbiddenValues))
+	for _, v := range r.forbiddenValues {
+		values = append(values, v)
+	}
+	return values
+}
+
+// GetRecommendation returns the recommended alternative to the forbidden values.
+func (r *ForbiddenRule[T]) GetRecommendation() string {
+	return r.recommendation
+}
+
+func (r *ForbiddenRule[T]) Name() string {
+	if r.ruleName != "" {
+		return r.ruleName
+	}
+
+	if r.nestedBlockType != nil {
+		return fmt.Sprintf("%s.%s.%s", r.resourceType, *r.nestedBlockType, r.attributeName)
+	}
+	return fmt.Sprintf("%s.%s", r.resourceType, r.attributeName)
+}
+
+func (r *ForbiddenRule[T]) Check(runner tflint.Runner) error {
+	var dt T
+	ctyType, err := toCtyType(dt)
+	if err != nil {
+		return err
+	}
+
+	if r.mustExist {
+		if missing, err := r.checkAttributeExists(runner, r); err != nil || missing {
+			return err
+		}
+	}
+
+	return r.checkValues(runner, r, cty.DynamicPseudoType, func(runner tflint.Runner, attr *hclext.Attribute, val cty.Value) error {
+		if val.IsNull() || !val.IsKnown() {
+			return nil
+		}
+		for _, forbidden := range r.forbiddenValues {
+			ctyForbidden, err := gocty.ToCtyValue(forbidden, ctyType)
+			if err != nil {
+				return err
+			}
+			if !ctyForbidden.Equals(val).True() {
+				continue
+			}
+			message := fmt.Sprintf("%v is a forbidden attribute value of `%s`", forbidden, r.attributeName)
+			if r.recommendation != "" {
+				message += fmt.Sprintf(" - use %s instead", r.recommendation)
+			}
+			return runner.EmitIssue(r, message, attr.Range)
+		}
+		return nil
+	})
+}
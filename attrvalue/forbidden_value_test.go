@@ -0,0 +1,149 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestForbiddenValueRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "allowed string",
+			rule: attrvalue.NewForbiddenRule("foo", "bar", []string{"Basic"}, "Standard", "", false, ""),
+			content: `
+	variable "test" {
+		type    = string
+		default = "Standard"
+	}
+	resource "foo" "example" {
+		bar = var.test
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "forbidden string from variable default",
+			rule: attrvalue.NewForbiddenRule("foo", "bar", []string{"Basic"}, "Standard", "", false, ""),
+			content: `
+	variable "test" {
+		type    = string
+		default = "Basic"
+	}
+	resource "foo" "example" {
+		bar = var.test
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewForbiddenRule("foo", "bar", []string{"Basic"}, "Standard", "", false, ""),
+					Message: "`var.test (default) -> foo.example.bar`: Basic is a forbidden attribute value of `bar` - use Standard instead",
+				},
+			},
+		},
+		{
+			name: "one of several forbidden strings",
+			rule: attrvalue.NewForbiddenRule("foo", "bar", []string{"1.0", "1.1"}, "1.2", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = "1.1"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewForbiddenRule("foo", "bar", []string{"1.0", "1.1"}, "1.2", "", false, ""),
+					Message: "1.1 is a forbidden attribute value of `bar` - use 1.2 instead",
+				},
+			},
+		},
+		{
+			name: "forbidden bool without recommendation",
+			rule: attrvalue.NewForbiddenRule("foo", "bar", []bool{true}, "", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = true
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewForbiddenRule("foo", "bar", []bool{true}, "", "", false, ""),
+					Message: "true is a forbidden attribute value of `bar`",
+				},
+			},
+		},
+		{
+			name: "forbidden number",
+			rule: attrvalue.NewForbiddenRule("foo", "bar", []int{1}, "2 or more", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = 1
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewForbiddenRule("foo", "bar", []int{1}, "2 or more", "", false, ""),
+					Message: "1 is a forbidden attribute value of `bar` - use 2 or more instead",
+				},
+			},
+		},
+		{
+			name: "unknown value",
+			rule: attrvalue.NewForbiddenRule("foo", "bar", []bool{true}, "false", "", false, ""),
+			content: `
+	variable "test" {
+		type = bool
+	}
+	resource "foo" "example" {
+		bar = var.test
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "missing attribute with must exist set",
+			rule: attrvalue.NewForbiddenRule("foo", "bar", []bool{true}, "false", "", true, ""),
+			content: `
+	resource "foo" "example" {
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewForbiddenRule("foo", "bar", []bool{true}, "false", "", true, ""),
+					Message: "The attribute `bar` must be specified",
+				},
+			},
+		},
+		{
+			name: "forbidden value in nested block",
+			rule: attrvalue.NewForbiddenNestedBlockRule("foo", "fiz", "bar", []string{"Basic"}, "Standard", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		fiz {
+			bar = "Basic"
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewForbiddenNestedBlockRule("foo", "fiz", "bar", []string{"Basic"}, "Standard", "", false, ""),
+					Message: "Basic is a forbidden attribute value of `bar` - use Standard instead",
+				},
+			},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
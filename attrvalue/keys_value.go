@@ -0,0 +1,139 @@
+package attrvalue
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// KeysRule checks the keys of a map or object attribute, e.g. the tags or app settings of a resource.
+// Either the keys are required, or they are forbidden.
+// Keys set to null are not specified, as for a provider.
+type KeysRule struct {
+	tflint.DefaultRule // Embed the default rule to reuse its implementation
+	baseValue
+	keys      []string // e.g. []string{"environment", "owner"}
+	forbidden bool     // whether the keys are forbidden rather than required
+	mustExist bool
+	ruleName  string
+}
+
+var _ tflint.Rule = (*KeysRule)(nil)
+var _ AttrValueRule = (*KeysRule)(nil)
+
+// NewRequiredKeysRule returns a new rule with the given resource type, attribute name, and keys the attribute must have.
+func NewRequiredKeysRule(resourceType, attributeName string, keys []string, link string, mustExist bool, ruleName string) *KeysRule {
+	return &KeysRule{
+		baseValue: newBaseValue(resourceType, nil, attributeName, true, link, tflint.ERROR),
+		keys:      keys,
+		mustExist: mustExist,
+		ruleName:  ruleName,
+	}
+}
+
+// NewRequiredKeysNestedBlockRule returns a new rule with the given resource type, nested block type, attribute name, and keys the attribute must have.
+func NewRequiredKeysNestedBlockRule(resourceType, nestedBlockType, attributeName string, keys []string, link string, mustExist bool, ruleName string) *KeysRule {
+	return &KeysRule{
+		baseValue: newBaseValue(resourceType, &nestedBlockType, attributeName, true, link, tflint.ERROR),
+		keys:      keys,
+		mustExist: mustExist,
+		ruleName:  ruleName,
+	}
+}
+
+// NewForbiddenKeysRule returns a new rule with the given resource type, attribute name, and keys the attribute must not have.
+func NewForbiddenKeysRule(resourceType, attributeName string, keys []string, link string, mustExist bool, ruleName string) *KeysRule {
+	return &KeysRule{
+		baseValue: newBaseValue(resourceType, nil, attributeName, true, link, tflint.ERROR),
+		keys:      keys,
+		forbidden: true,
+		mustExist: mustExist,
+		ruleName:  ruleName,
+	}
+}
+
+// NewForbiddenKeysNestedBlockRule returns a new rule with the given resource type, nested block type, attribute name, and keys the attribute must not have.
+func NewForbiddenKeysNestedBlockRule(resourceType, nestedBlockType, attributeName string, keys []string, link string, mustExist bool, ruleName string) *KeysRule {
+	return &KeysRule{
+		baseValue: newBaseValue(resourceType, &nestedBlockType, attributeName, true, link, tflint.ERROR),
+		keys:      keys,
+		forbidden: true,
+		mustExist: mustExist,
+		ruleName:  ruleName,
+	}
+}
+
+func (r *KeysRule) Link() string {
+	return r.link
+}
+
+// GetKeys returns the keys the attribute must have, or must not have if KeysForbidden.
+func (r *KeysRule) GetKeys() []string {
+	return r.keys
+}
+
+// KeysForbidden returns whether the keys are forbidden rather than required.
+func (r *KeysRule) KeysForbidden() bool {
+	return r.forbidden
+}
+
+func (r *KeysRule) Name() string {
+	if r.ruleName != "" {
+		return r.ruleName
+	}
+
+	if r.nestedBlockType != nil {
+		return fmt.Sprintf("%s.%s.%s", r.resourceType, *r.nestedBlockType, r.attributeName)
+	}
+	return fmt.Sprintf("%s.%s", r.resourceType, r.attributeName)
+}
+
+func (r *KeysRule) Check(runner tflint.Runner) error {
+	if r.mustExist {
+		if missing, err := r.checkAttributeExists(runner, r); err != nil || missing {
+			return err
+		}
+	}
+
+	return r.checkValues(runner, r, cty.DynamicPseudoType, func(runner tflint.Runner, attr *hclext.Attribute, val cty.Value) error {
+		if val.IsNull() || !val.IsKnown() {
+			return nil
+		}
+		if !val.Type().IsMapType() && !val.Type().IsObjectType() {
+			return nil
+		}
+		specified := specifiedKeys(val)
+		for _, key := range r.keys {
+			if slices.Contains(specified, key) == r.forbidden {
+				if err := runner.EmitIssue(r, r.message(key), attr.Range); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (r *KeysRule) message(key string) string {
+	if r.forbidden {
+		return fmt.Sprintf("The key `%s` of `%s` is forbidden", key, r.attributeName)
+	}
+	return fmt.Sprintf("The key `%s` of `%s` must be specified", key, r.attributeName)
+}
+
+// specifiedKeys returns the keys of a map or object value whose value is not null.
+// Keys with an unknown value are specified.
+func specifiedKeys(val cty.Value) []string {
+	var keys []string
+	for it := val.ElementIterator(); it.Next(); {
+		key, v := it.Element()
+		if v.IsKnown() && v.IsNull() {
+			continue
+		}
+		keys = append(keys, key.AsString())
+	}
+	return keys
+}
@@ -0,0 +1,166 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestKeysRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "required keys specified",
+			rule: attrvalue.NewRequiredKeysRule("foo", "tags", []string{"environment", "owner"}, "", false, ""),
+			content: `
+	resource "foo" "example" {
+		tags = {
+			environment = "prod"
+			owner       = "platform"
+			project     = "avm"
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "required keys missing",
+			rule: attrvalue.NewRequiredKeysRule("foo", "tags", []string{"environment", "owner"}, "", false, ""),
+			content: `
+	variable "tags" {
+		type = map(string)
+		default = {
+			environment = "prod"
+		}
+	}
+	resource "foo" "example" {
+		tags = var.tags
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRequiredKeysRule("foo", "tags", []string{"environment", "owner"}, "", false, ""),
+					Message: "`var.tags (default) -> foo.example.tags`: The key `owner` of `tags` must be specified",
+				},
+			},
+		},
+		{
+			name: "required key set to null",
+			rule: attrvalue.NewRequiredKeysRule("foo", "network_rules", []string{"default_action"}, "", false, ""),
+			content: `
+	variable "network_rules" {
+		type = object({
+			default_action = optional(string)
+			bypass         = optional(list(string))
+		})
+		default = {
+			bypass = ["AzureServices"]
+		}
+	}
+	resource "foo" "example" {
+		network_rules = var.network_rules
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRequiredKeysRule("foo", "network_rules", []string{"default_action"}, "", false, ""),
+					Message: "`var.network_rules (default) -> foo.example.network_rules`: The key `default_action` of `network_rules` must be specified",
+				},
+			},
+		},
+		{
+			name: "required keys with unknown value",
+			rule: attrvalue.NewRequiredKeysRule("foo", "tags", []string{"environment"}, "", false, ""),
+			content: `
+	variable "tags" {
+		type = map(string)
+	}
+	resource "foo" "example" {
+		tags = var.tags
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "required keys with missing attribute",
+			rule: attrvalue.NewRequiredKeysRule("foo", "tags", []string{"environment"}, "", true, ""),
+			content: `
+	resource "foo" "example" {
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRequiredKeysRule("foo", "tags", []string{"environment"}, "", true, ""),
+					Message: "The attribute `tags` must be specified",
+				},
+			},
+		},
+		{
+			name: "forbidden keys specified",
+			rule: attrvalue.NewForbiddenKeysRule("foo", "app_settings", []string{"WEBSITE_RUN_FROM_PACKAGE", "WEBSITE_NODE_DEFAULT_VERSION"}, "", false, ""),
+			content: `
+	resource "foo" "example" {
+		app_settings = {
+			WEBSITE_RUN_FROM_PACKAGE     = "1"
+			WEBSITE_NODE_DEFAULT_VERSION = "~18"
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewForbiddenKeysRule("foo", "app_settings", []string{"WEBSITE_RUN_FROM_PACKAGE", "WEBSITE_NODE_DEFAULT_VERSION"}, "", false, ""),
+					Message: "The key `WEBSITE_RUN_FROM_PACKAGE` of `app_settings` is forbidden",
+				},
+				{
+					Rule:    attrvalue.NewForbiddenKeysRule("foo", "app_settings", []string{"WEBSITE_RUN_FROM_PACKAGE", "WEBSITE_NODE_DEFAULT_VERSION"}, "", false, ""),
+					Message: "The key `WEBSITE_NODE_DEFAULT_VERSION` of `app_settings` is forbidden",
+				},
+			},
+		},
+		{
+			name: "forbidden keys not specified",
+			rule: attrvalue.NewForbiddenKeysRule("foo", "app_settings", []string{"WEBSITE_RUN_FROM_PACKAGE"}, "", false, ""),
+			content: `
+	resource "foo" "example" {
+		app_settings = {
+			FUNCTIONS_WORKER_RUNTIME = "dotnet"
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "forbidden keys in nested block",
+			rule: attrvalue.NewForbiddenKeysNestedBlockRule("foo", "site_config", "app_settings", []string{"WEBSITE_RUN_FROM_PACKAGE"}, "", false, ""),
+			content: `
+	resource "foo" "example" {
+		site_config {
+			app_settings = {
+				WEBSITE_RUN_FROM_PACKAGE = "1"
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewForbiddenKeysNestedBlockRule("foo", "site_config", "app_settings", []string{"WEBSITE_RUN_FROM_PACKAGE"}, "", false, ""),
+					Message: "The key `WEBSITE_RUN_FROM_PACKAGE` of `app_settings` is forbidden",
+				},
+			},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package attrvalue
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// moduleInstance is a module of the configuration with an evaluator for its expressions.
+// The root module has no caller. A local module is instantiated once per module call, or once per count index or for_each key of the call,
+// with its variables bound to the arguments of the call.
+type moduleInstance struct {
+	config    *terraform.Config
+	ctx       *terraform.Evaluator
+	address   string            // e.g. `module.lb["a"]`, empty for the root module
+	caller    *moduleInstance   // nil for the root module
+	arguments hclext.Attributes // The arguments of the module call, keyed by variable name
+	callRange hcl.Range         // The range of the module call in the root module the instance comes from, e.g. `module "pattern"` for `module.pattern.module.lb`
+}
+
+// moduleInstances returns the root module and the instances of the local modules it calls, at any depth.
+// Module calls with an unknown count or for_each have no instance, as they are not expanded by the evaluator.
+func moduleInstances(config *terraform.Config, ctx *terraform.Evaluator) ([]*moduleInstance, hcl.Diagnostics) {
+	root := &moduleInstance{config: config, ctx: ctx}
+	children, diags := root.children()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return append([]*moduleInstance{root}, children...), nil
+}
+
+// children returns the instances of the local modules called by the module, and of the modules they call.
+func (m *moduleInstance) children() ([]*moduleInstance, hcl.Diagnostics) {
+	names := make([]string, 0, len(m.config.Children))
+	for name := range m.config.Children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var instances []*moduleInstance
+	for _, name := range names {
+		child := m.config.Children[name]
+		schema := &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "count"}, {Name: "for_each"}},
+		}
+		for variable := range child.Module.Variables {
+			schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: variable})
+		}
+		calls, diags := m.config.Module.PartialContent(&hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "module",
+					LabelNames: []string{"name"},
+					Body:       schema,
+				},
+			},
+		}, m.ctx)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		for _, expanded := range expandedBlocks(calls.Blocks) {
+			if expanded[0].Labels[0] != name {
+				continue
+			}
+			keys, _, diags := instanceKeys(expanded[0], m.ctx)
+			if diags.HasErrors() {
+				return nil, diags
+			}
+			for n, call := range expanded {
+				key := cty.NilVal
+				if len(keys) == len(expanded) {
+					key = keys[n]
+				}
+				instance, diags := m.call(child, call, key)
+				if diags.HasErrors() {
+					return nil, diags
+				}
+				children, diags := instance.children()
+				if diags.HasErrors() {
+					return nil, diags
+				}
+				instances = append(instances, instance)
+				instances = append(instances, children...)
+			}
+		}
+	}
+	return instances, nil
+}
+
+// call returns the instance of the child module called by the module call block,
+// whose variables are bound to the values of the arguments in the module.
+func (m *moduleInstance) call(child *terraform.Config, call *hclext.Block, key cty.Value) (*moduleInstance, hcl.Diagnostics) {
+	arguments := hclext.Attributes{}
+	inputs := terraform.InputValues{}
+	for name, attr := range call.Body.Attributes {
+		if name == "count" || name == "for_each" {
+			continue
+		}
+		val, diags := m.ctx.EvaluateExpr(attr.Expr, cty.DynamicPseudoType)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		arguments[name] = attr
+		inputs[name] = &terraform.InputValue{Value: val}
+	}
+	vvals, diags := terraform.VariableValues(child, inputs)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	address := instanceAddress(fmt.Sprintf("module.%s", call.Labels[0]), key)
+	callRange := call.DefRange
+	if m.address != "" {
+		address = fmt.Sprintf("%s.%s", m.address, address)
+		callRange = m.callRange
+	}
+	return &moduleInstance{
+		config: child,
+		ctx: &terraform.Evaluator{
+			Meta:           m.ctx.Meta,
+			Config:         m.ctx.Config,
+			VariableValues: vvals,
+			ModulePath:     child.Path.UnkeyedInstanceShim(),
+		},
+		address:   address,
+		caller:    m,
+		arguments: arguments,
+		callRange: callRange,
+	}, nil
+}
+
+// rootArguments returns the arguments of the module calls in the root module the expression gets its value from,
+// following the variables of the module up the module calls.
+func (m *moduleInstance) rootArguments(expr hcl.Expression) []*moduleArgument {
+	var arguments []*moduleArgument
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "var" || len(traversal) < 2 {
+			continue
+		}
+		variable, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+		argument, ok := m.arguments[variable.Name]
+		if !ok {
+			continue
+		}
+		if m.caller.caller == nil {
+			arguments = append(arguments, &moduleArgument{Attribute: argument, module: m})
+			continue
+		}
+		arguments = append(arguments, m.caller.rootArguments(argument.Expr)...)
+	}
+	return arguments
+}
+
+// moduleArgument is an argument of a module call in the root module.
+type moduleArgument struct {
+	*hclext.Attribute
+	module *moduleInstance // The called module instance
+}
+
+var _ tflint.Runner = new(moduleCallRunner)
+
+// moduleCallRunner reports the issues found in a called module at the arguments of the module calls in the root module
+// that the checked expression gets its value from. Other issues are not reported, they are found when checking the called module itself.
+// An argument that gets its value from a variable or a local value of the root module is traced to its origin, see traceOrigin.
+// A missing attribute, which no argument can specify, is reported at the module call in the root module.
+type moduleCallRunner struct {
+	tflint.Runner
+	module    *moduleInstance
+	address   string
+	attribute string         // The checked attribute, e.g. `sku`
+	expr      hcl.Expression // The checked expression, nil if the attribute is missing
+}
+
+func (r *moduleCallRunner) EmitIssue(rule tflint.Rule, message string, _ hcl.Range) error {
+	if r.expr == nil {
+		return r.Runner.EmitIssue(rule, fmt.Sprintf("`%s`: %s", r.address, message), r.module.callRange)
+	}
+	for _, argument := range r.module.rootArguments(r.expr) {
+		issueMessage, issueRange := fmt.Sprintf("`%s`: %s", r.address, message), argument.Range
+		if origin := traceOrigin(argument.module.caller, argument.Expr); origin != nil {
+			chain := append(origin.chain, fmt.Sprintf("%s.%s", argument.module.address, argument.Name), fmt.Sprintf("%s.%s", r.address, r.attribute))
+			issueMessage, issueRange = fmt.Sprintf("`%s`: %s", strings.Join(chain, " -> "), message), origin.rng
+		}
+		if err := r.Runner.EmitIssue(rule, issueMessage, issueRange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package attrvalue_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const lbModule = `
+	variable "sku" {
+		type    = string
+		default = "Standard"
+	}
+	resource "foo" "this" {
+		sku = var.sku
+	}`
+
+func TestModuleCallValueRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "argument breaking the rule",
+			rule: attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+			files: map[string]string{
+				"main.tf": `
+	module "lb" {
+		source = "./modules/lb"
+		sku    = "Basic"
+	}`,
+				"modules/lb/main.tf": lbModule,
+			},
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+					Message: "`module.lb.foo.this`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+				},
+			},
+		},
+		{
+			name: "example calling the module in the parent directory",
+			rule: attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+			files: map[string]string{
+				"main.tf": `
+	module "lb" {
+		source = "../.."
+		sku    = "Basic"
+	}`,
+				"../../main.tf": lbModule,
+			},
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+					Message: "`module.lb.foo.this`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+				},
+			},
+		},
+		{
+			name: "argument meeting the rule",
+			rule: attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+			files: map[string]string{
+				"main.tf": `
+	module "lb" {
+		source = "./modules/lb"
+		sku    = "Standard"
+	}`,
+				"modules/lb/main.tf": lbModule,
+			},
+			expected: helper.Issues{},
+		},
+		{
+			name: "value not from an argument",
+			rule: attrvalue.NewSimpleRule("foo", "sku", []string{"Premium"}, "", false, ""),
+			files: map[string]string{
+				"main.tf": `
+	module "lb" {
+		source = "./modules/lb"
+	}`,
+				"modules/lb/main.tf": lbModule,
+			},
+			expected: helper.Issues{},
+		},
+		{
+			name: "attribute missing in the module",
+			rule: attrvalue.NewSimpleRule("foo", "zones", []string{"1"}, "", true, ""),
+			files: map[string]string{
+				"main.tf": `
+	module "lb" {
+		source = "./modules/lb"
+		sku    = "Basic"
+	}`,
+				"modules/lb/main.tf": lbModule,
+			},
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleRule("foo", "zones", []string{"1"}, "", true, ""),
+					Message: "`module.lb.foo.this`: The attribute `zones` must be specified",
+				},
+			},
+		},
+		{
+			name: "argument of a variable of the caller",
+			rule: attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+			files: map[string]string{
+				"main.tf": `
+	module "pattern" {
+		source = "./modules/pattern"
+		lb_sku = "Basic"
+	}`,
+				"modules/pattern/main.tf": `
+	variable "lb_sku" {
+		type = string
+	}
+	module "lb" {
+		source = "../lb"
+		sku    = var.lb_sku
+	}`,
+				"modules/lb/main.tf": lbModule,
+			},
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+					Message: "`module.pattern.module.lb.foo.this`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+				},
+			},
+		},
+		{
+			name: "module call with for_each",
+			rule: attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+			files: map[string]string{
+				"main.tf": `
+	module "lb" {
+		source   = "./modules/lb"
+		for_each = { a = "Standard", b = "Basic" }
+		sku      = each.value
+	}`,
+				"modules/lb/main.tf": lbModule,
+			},
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+					Message: "`module.lb[\"b\"].foo.this`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+				},
+			},
+		},
+		{
+			name: "resource with count in the module",
+			rule: attrvalue.NewRangeRule("foo", "retention_days", attrvalue.Inclusive(7), nil, "", false, ""),
+			files: map[string]string{
+				"main.tf": `
+	module "logs" {
+		source         = "./modules/logs"
+		retention_days = [7, 3]
+	}`,
+				"modules/logs/main.tf": `
+	variable "retention_days" {
+		type = list(number)
+	}
+	resource "foo" "this" {
+		count          = length(var.retention_days)
+		retention_days = var.retention_days[count.index]
+	}`,
+			},
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRangeRule("foo", "retention_days", attrvalue.Inclusive(7), nil, "", false, ""),
+					Message: "`module.logs.foo.this[1]`: 3 is an invalid attribute value of `retention_days` - expecting a value >= 7",
+				},
+			},
+		},
+	}
+
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.files["main.tf"]})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFiles(tc.files))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
+
+func TestModuleCallIssueRange(t *testing.T) {
+	files := map[string]string{
+		"main.tf": `module "lb" {
+  source = "./modules/lb"
+  sku    = "Basic"
+}`,
+		"modules/lb/main.tf": lbModule,
+	}
+	runner := helper.TestRunner(t, map[string]string{"main.tf": files["main.tf"]})
+	stub := gostub.Stub(&attrvalue.AppFs, mockFiles(files))
+	defer stub.Reset()
+
+	rule := attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, "")
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if assert.Len(t, runner.Issues, 1) {
+		assert.Equal(t, "main.tf", runner.Issues[0].Range.Filename)
+		assert.Equal(t, 3, runner.Issues[0].Range.Start.Line)
+	}
+}
+
+func TestModuleCallMissingAttributeRange(t *testing.T) {
+	files := map[string]string{
+		"main.tf": `locals {}
+
+module "lb" {
+  source = "./modules/lb"
+}`,
+		"modules/lb/main.tf": lbModule,
+	}
+	runner := helper.TestRunner(t, map[string]string{"main.tf": files["main.tf"]})
+	stub := gostub.Stub(&attrvalue.AppFs, mockFiles(files))
+	defer stub.Reset()
+
+	rule := attrvalue.NewMustExistRule("foo", "zones", "", "")
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if assert.Len(t, runner.Issues, 1) {
+		assert.Equal(t, "`module.lb.foo.this`: The attribute `zones` must be specified", runner.Issues[0].Message)
+		assert.Equal(t, "main.tf", runner.Issues[0].Range.Filename)
+		assert.Equal(t, 3, runner.Issues[0].Range.Start.Line)
+	}
+}
+
+func mockFiles(files map[string]string) afero.Afero {
+	fs := afero.NewMemMapFs()
+	for name, content := range files {
+		_ = afero.WriteFile(fs, name, []byte(content), os.ModePerm)
+	}
+	return afero.Afero{Fs: fs}
+}
@@ -1,12 +1,15 @@
 package attrvalue
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/spf13/afero"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint/terraform"
 	"github.com/terraform-linters/tflint/terraform/addrs"
+	"github.com/zclconf/go-cty/cty"
 )
 
 var AppFs = afero.Afero{
@@ -15,78 +18,315 @@ var AppFs = afero.Afero{
 
 type AttrValueRule interface {
 	GetResourceType() string
+	GetBlockType() string
+	GetArmType() string
 	GetNestedBlockType() *string
 	GetAttributeName() string
 }
 
-// getSimpleResources returns a slice of resources with the given resource type and the attribute if it exists.
-func getSimpleResourcesWithAttributes(module *terraform.Module, resourceType string, attributeName string, ctx *terraform.Evaluator) ([]*hclext.Block, hcl.Diagnostics) {
-	resources, diags := getResourcesOfResourceTypeIncludingSpecifiedAttribute(module, attributeName, ctx)
-	if diags.HasErrors() {
-		return nil, diags
+// ExpectedValuesRule is implemented by the rules that check an attribute against a list of expected values.
+type ExpectedValuesRule interface {
+	AttrValueRule
+	GetExpectedValues() []any
+}
+
+// ForbiddenValuesRule is implemented by the rules that check an attribute against a list of forbidden values.
+type ForbiddenValuesRule interface {
+	AttrValueRule
+	GetForbiddenValues() []any
+	GetRecommendation() string
+}
+
+// resourceInstance is a resource block, expanded by count or for_each if it has either.
+// It can also be a data source or a module call, for the rules checking these, see On.
+type resourceInstance struct {
+	*hclext.Block
+	key    cty.Value       // The count index or the for_each key of the instance, cty.NilVal if the resource has neither.
+	each   cty.Value       // The for_each value of the instance, cty.NilVal if the resource has no for_each.
+	module *moduleInstance // The module instance the resource is in, see fetchResources.
+	issues *originIssues   // The issues found at the origin of the checked values, shared by the checked instances, see eachAttribute.
+}
+
+// address returns the address of the resource instance, e.g. `azurerm_public_ip.this["pip1"]` or `module.lb.azurerm_lb.this`.
+// Data sources are prefixed with `data.` and module calls are addressed by their name, e.g. `module.lb`.
+func (i *resourceInstance) address() string {
+	var address string
+	switch i.Type {
+	case BlockTypeData:
+		address = fmt.Sprintf("data.%s.%s", i.Labels[0], i.Labels[1])
+	case BlockTypeModule:
+		address = fmt.Sprintf("module.%s", i.Labels[0])
+	default:
+		address = fmt.Sprintf("%s.%s", i.Labels[0], i.Labels[1])
 	}
-	filteredResources := make([]*hclext.Block, 0, len(resources.Blocks))
-	for _, resource := range resources.Blocks {
-		if resource.Labels[0] != resourceType {
-			continue
+	address = instanceAddress(address, i.key)
+	if i.module.address != "" {
+		return fmt.Sprintf("%s.%s", i.module.address, address)
+	}
+	return address
+}
+
+// instanceAddress returns the address of the instance of a resource or module call with the given count index or for_each key.
+func instanceAddress(address string, key cty.Value) string {
+	switch {
+	case key.Type() == cty.NilType:
+		return address
+	case key.Type() == cty.String:
+		return fmt.Sprintf("%s[%q]", address, key.AsString())
+	default:
+		return fmt.Sprintf("%s[%s]", address, key.AsBigFloat().Text('f', -1))
+	}
+}
+
+// runner returns a runner reporting the issues found in the checked expression of the resource instance, nil if the attribute is missing.
+// The attribute is the path of the checked attribute in the resource, e.g. `site_config.ftps_state`.
+// The messages of the issues found in an instance of a resource with count or for_each are prefixed with the address of the instance,
+// so that the count index or for_each key breaking the rule can be told apart.
+// The issues found in an attribute referring to a variable or a local value are reported at the origin of the value, see traceOrigin,
+// and those found in a called module at the module call arguments, see moduleCallRunner.
+func (i *resourceInstance) runner(runner tflint.Runner, expr hcl.Expression, attribute string) tflint.Runner {
+	if i.module.caller != nil {
+		return &moduleCallRunner{
+			Runner:    runner,
+			module:    i.module,
+			address:   i.address(),
+			attribute: attribute,
+			expr:      expr,
 		}
-		filteredResources = append(filteredResources, resource)
 	}
-	return filteredResources, nil
+	if expr != nil {
+		if origin := traceOrigin(i.module, expr); origin != nil {
+			origin.chain = append(origin.chain, fmt.Sprintf("%s.%s", i.address(), attribute))
+			return &originRunner{
+				Runner: runner,
+				origin: origin,
+				issues: i.issues,
+			}
+		}
+	}
+	if i.key.Type() == cty.NilType {
+		return runner
+	}
+	return &instanceRunner{
+		Runner:  runner,
+		address: i.address(),
+	}
 }
 
-// getSimpleAttrs returns a slice of attributes with the given attribute name from the resources of the given resource type.
-func getSimpleAttrs(module *terraform.Module, resourceType string, attributeName string, ctx *terraform.Evaluator) ([]*hclext.Attribute, hcl.Diagnostics) {
-	resources, diags := getResourcesOfResourceTypeIncludingSpecifiedAttribute(module, attributeName, ctx)
+var _ tflint.Runner = new(instanceRunner)
+
+type instanceRunner struct {
+	tflint.Runner
+	address string
+}
+
+func (r *instanceRunner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
+	return r.Runner.EmitIssue(rule, fmt.Sprintf("`%s`: %s", r.address, message), issueRange)
+}
+
+// getResources returns the instances of the blocks of the given kind and resource type that meet the conditions,
+// with the nested blocks along the block path and the attribute if they exist.
+// Resources and module calls with count or for_each are expanded into one instance per count index or for_each key when these are known.
+func getResources(module *terraform.Module, blockType, resourceType string, blockPath []string, attributeName string, conditions []*Condition, ctx *terraform.Evaluator) ([]*resourceInstance, hcl.Diagnostics) {
+	schema := blockPathSchema(blockPath, attributeName)
+	for _, condition := range conditions {
+		addAttribute(schema, condition.rootAttribute())
+	}
+	addAttribute(schema, "count")
+	addAttribute(schema, "for_each")
+	labelNames := []string{"type", "name"}
+	if blockType == BlockTypeModule {
+		labelNames = []string{"name"}
+		addAttribute(schema, "source")
+	}
+	resources, diags := module.PartialContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       blockType,
+				LabelNames: labelNames,
+				Body:       schema,
+			},
+		},
+	}, ctx)
 	if diags.HasErrors() {
 		return nil, diags
 	}
-	attrs := make([]*hclext.Attribute, 0, len(resources.Blocks))
-	for _, resource := range resources.Blocks {
-		if resource.Labels[0] != resourceType {
+	instances, diags := resourceInstances(resources.Blocks, resourceType, ctx)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return filterResources(instances, conditions, ctx)
+}
+
+// resourceInstances returns the instances of the resources of the given resource type.
+// The evaluator expands a resource with count or for_each into consecutive blocks with the same definition range,
+// in the order of the count indexes or for_each keys, which gives the key of each instance.
+func resourceInstances(resources hclext.Blocks, resourceType string, ctx *terraform.Evaluator) ([]*resourceInstance, hcl.Diagnostics) {
+	var instances []*resourceInstance
+	for _, expanded := range expandedBlocks(resources) {
+		if !isOfType(expanded[0], resourceType) {
 			continue
 		}
-		if attribute := getAttrFromBlock(resource, attributeName); attribute != nil {
-			attrs = append(attrs, attribute)
+		keys, values, diags := instanceKeys(expanded[0], ctx)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		for n, block := range expanded {
+			instance := &resourceInstance{Block: block}
+			if len(keys) == len(expanded) {
+				instance.key = keys[n]
+			}
+			if len(values) == len(expanded) {
+				instance.each = values[n]
+			}
+			instances = append(instances, instance)
 		}
 	}
-	return attrs, nil
+	return instances, nil
 }
 
-// getNestedResourcesWithAttribute returns a slice of resources with the given resource type and the attribute if it exists.
-func getNestedResourcesWithBlockAttributes(ctx *terraform.Evaluator, module *terraform.Module, resourceType, nestedBlockType, attributeName string) ([]*hclext.Block, hcl.Diagnostics) {
-	resources, diags := getResourcesOfResourceTypeIncludingBlocksWithSpecifiedAttribute(module, nestedBlockType, attributeName, ctx)
-	if diags.HasErrors() {
-		return nil, diags
+// isOfType returns whether the block is of the resource type, i.e. the type label of a resource or data source,
+// or the source of a module call.
+func isOfType(block *hclext.Block, resourceType string) bool {
+	if block.Type != BlockTypeModule {
+		return block.Labels[0] == resourceType
 	}
-	filteredResources := make([]*hclext.Block, 0, len(resources.Blocks))
-	for _, resource := range resources.Blocks {
-		if resource.Labels[0] != resourceType {
-			continue
+	source, ok := block.Body.Attributes["source"]
+	if !ok {
+		return false
+	}
+	val, diags := source.Expr.Value(nil)
+	return !diags.HasErrors() && val.Type() == cty.String && val.IsKnown() && !val.IsNull() && val.AsString() == resourceType
+}
+
+// expandedBlocks groups the consecutive blocks with the same definition range,
+// i.e. the blocks the evaluator expanded a block with count or for_each into.
+func expandedBlocks(blocks hclext.Blocks) []hclext.Blocks {
+	var groups []hclext.Blocks
+	for i := 0; i < len(blocks); {
+		j := i + 1
+		for j < len(blocks) && blocks[j].DefRange == blocks[i].DefRange {
+			j++
 		}
-		filteredResources = append(filteredResources, resource)
+		groups = append(groups, blocks[i:j])
+		i = j
 	}
-	return filteredResources, nil
+	return groups
 }
 
-// getNestedBlockAttrs returns a slice of attributes with the given attribute name from the nested blocks of the given resource type.
-func getNestedBlockAttrs(ctx *terraform.Evaluator, module *terraform.Module, resourceType, nestedBlockType, attributeName string) ([]*hclext.Attribute, hcl.Diagnostics) {
-	resources, diags := getResourcesOfResourceTypeIncludingBlocksWithSpecifiedAttribute(module, nestedBlockType, attributeName, ctx)
-	if diags.HasErrors() {
-		return nil, diags
+// instanceKeys returns the count indexes or for_each keys of the resource, in order, and the for_each values if it has for_each.
+// It returns nil if the resource has neither count nor for_each, or if they are not known.
+func instanceKeys(resource *hclext.Block, ctx *terraform.Evaluator) ([]cty.Value, []cty.Value, hcl.Diagnostics) {
+	if count, ok := resource.Body.Attributes["count"]; ok {
+		val, diags := ctx.EvaluateExpr(count.Expr, cty.Number)
+		if diags.HasErrors() || !val.IsKnown() || val.IsNull() {
+			return nil, nil, diags
+		}
+		n, _ := val.AsBigFloat().Int64()
+		keys := make([]cty.Value, 0, n)
+		for i := int64(0); i < n; i++ {
+			keys = append(keys, cty.NumberIntVal(i))
+		}
+		return keys, nil, nil
 	}
-	attrs := make([]*hclext.Attribute, 0, len(resources.Blocks))
-	for _, resource := range resources.Blocks {
-		if resource.Labels[0] != resourceType {
-			continue
+	if forEach, ok := resource.Body.Attributes["for_each"]; ok {
+		val, diags := ctx.EvaluateExpr(forEach.Expr, cty.DynamicPseudoType)
+		if diags.HasErrors() || !val.IsKnown() || val.IsNull() || !val.CanIterateElements() {
+			return nil, nil, diags
+		}
+		var keys, values []cty.Value
+		for it := val.ElementIterator(); it.Next(); {
+			key, value := it.Element()
+			keys = append(keys, key)
+			values = append(values, value)
 		}
-		for _, block := range resource.Body.Blocks {
-			if attr := getAttrFromBlock(block, attributeName); attr != nil {
-				attrs = append(attrs, attr)
+		return keys, values, nil
+	}
+	return nil, nil, nil
+}
+
+// blockPathSchema returns the schema of a resource body down to the attribute at the end of the block path.
+func blockPathSchema(blockPath []string, attributeName string) *hclext.BodySchema {
+	if len(blockPath) == 0 {
+		return &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{
+				{
+					Name:     attributeName,
+					Required: false,
+				},
+			},
+		}
+	}
+	return &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: blockPath[0],
+				Body: blockPathSchema(blockPath[1:], attributeName),
+			},
+		},
+	}
+}
+
+// blocksAtPath returns the nested blocks at the end of the block path, or the block itself if the path is empty.
+func blocksAtPath(block *hclext.Block, blockPath []string) []*hclext.Block {
+	if len(blockPath) == 0 {
+		return []*hclext.Block{block}
+	}
+	var blocks []*hclext.Block
+	for _, nested := range block.Body.Blocks.OfType(blockPath[0]) {
+		blocks = append(blocks, blocksAtPath(nested, blockPath[1:])...)
+	}
+	return blocks
+}
+
+// blockMissingAttribute returns the first block along the block path that is missing the next nested block,
+// or the block at the end of the path that is missing the attribute. It returns nil if nothing is missing.
+func blockMissingAttribute(block *hclext.Block, blockPath []string, attributeName string) *hclext.Block {
+	if len(blockPath) == 0 {
+		if getAttrFromBlock(block, attributeName) == nil {
+			return block
+		}
+		return nil
+	}
+	nested := block.Body.Blocks.OfType(blockPath[0])
+	if len(nested) == 0 {
+		return block
+	}
+	for _, n := range nested {
+		if missing := blockMissingAttribute(n, blockPath[1:], attributeName); missing != nil {
+			return missing
+		}
+	}
+	return nil
+}
+
+// filterResources returns the resource instances that meet all the conditions.
+func filterResources(instances []*resourceInstance, conditions []*Condition, ctx *terraform.Evaluator) ([]*resourceInstance, hcl.Diagnostics) {
+	filtered := make([]*resourceInstance, 0, len(instances))
+	for _, instance := range instances {
+		matches := true
+		for _, condition := range conditions {
+			m, diags := condition.matches(instance.Block, ctx)
+			if diags.HasErrors() {
+				return nil, diags
 			}
+			matches = matches && m
+		}
+		if matches {
+			filtered = append(filtered, instance)
+		}
+	}
+	return filtered, nil
+}
+
+// addAttribute adds the attribute to the top level of the schema, unless it is there already, e.g. as the checked attribute.
+func addAttribute(schema *hclext.BodySchema, name string) {
+	for _, attr := range schema.Attributes {
+		if attr.Name == name {
+			return
 		}
 	}
-	return attrs, nil
+	schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: name})
 }
 
 // getAttrFromBlock returns the attribute with the given attribute name from the block.
@@ -98,7 +338,9 @@ func getAttrFromBlock(block *hclext.Block, attributeName string) *hclext.Attribu
 	return attribute
 }
 
-func fetchAttrsAndContext(r AttrValueRule, runner tflint.Runner) (*terraform.Evaluator, []*hclext.Attribute, hcl.Diagnostics) {
+// newEvaluator loads the module in the working directory of the runner, from AppFs, and returns it with an evaluator for its expressions.
+// Dynamic blocks are expanded when the module content is read, see expandUnknownDynamicBlocks.
+func newEvaluator(runner tflint.Runner) (*terraform.Config, *terraform.Evaluator, hcl.Diagnostics) {
 	// If we are using the tflint test runner then we need to create a new memory file system
 	wd, _ := runner.GetOriginalwd()
 	loader, err := terraform.NewLoader(AppFs, wd)
@@ -111,6 +353,7 @@ func fetchAttrsAndContext(r AttrValueRule, runner tflint.Runner) (*terraform.Eva
 	if diags.HasErrors() {
 		return nil, nil, diags
 	}
+	expandUnknownDynamicBlocks(config)
 	vvals, diags := terraform.VariableValues(config)
 	if diags.HasErrors() {
 		return nil, nil, diags
@@ -124,99 +367,30 @@ func fetchAttrsAndContext(r AttrValueRule, runner tflint.Runner) (*terraform.Eva
 		VariableValues: vvals,
 		ModulePath:     addrs.RootModuleInstance,
 	}
-
-	if r.GetNestedBlockType() != nil {
-		attrs, diags := getNestedBlockAttrs(ctx, config.Module, r.GetResourceType(), *r.GetNestedBlockType(), r.GetAttributeName())
-		return ctx, attrs, diags
-	}
-
-	attrs, diags := getSimpleAttrs(config.Module, r.GetResourceType(), r.GetAttributeName(), ctx)
-
-	return ctx, attrs, diags
+	return config, ctx, nil
 }
 
-func fetchResourcesAndContext(r AttrValueRule, runner tflint.Runner) (*terraform.Evaluator, []*hclext.Block, hcl.Diagnostics) {
-	// If we are using the tflint test runner then we need to create a new memory file system
-	wd, _ := runner.GetOriginalwd()
-	loader, err := terraform.NewLoader(AppFs, wd)
-	if err != nil {
-		return nil, nil, hcl.Diagnostics{{
-			Summary: err.Error(),
-		}}
-	}
-	config, diags := loader.LoadConfig(".", terraform.CallLocalModule)
+// fetchResources returns the instances of the resources checked by the rule, in the root module and in the local modules it calls.
+// Each instance refers to its module instance, whose evaluator evaluates the expressions of the resource.
+func fetchResources(r baseValue, runner tflint.Runner) ([]*resourceInstance, hcl.Diagnostics) {
+	config, ctx, diags := newEvaluator(runner)
 	if diags.HasErrors() {
-		return nil, nil, diags
+		return nil, diags
 	}
-	vvals, diags := terraform.VariableValues(config)
+	modules, diags := moduleInstances(config, ctx)
 	if diags.HasErrors() {
-		return nil, nil, diags
-	}
-	ctx := &terraform.Evaluator{
-		Meta: &terraform.ContextMeta{
-			Env:                "",
-			OriginalWorkingDir: wd,
-		},
-		Config:         config,
-		VariableValues: vvals,
-		ModulePath:     addrs.RootModuleInstance,
+		return nil, diags
 	}
-
-	if r.GetNestedBlockType() != nil {
-		resources, diags := getNestedResourcesWithBlockAttributes(ctx, config.Module, r.GetResourceType(), *r.GetNestedBlockType(), r.GetAttributeName())
-		return ctx, resources, diags
+	var resources []*resourceInstance
+	for _, module := range modules {
+		instances, diags := getResources(module.config.Module, r.GetBlockType(), r.resourceType, r.blockPath, r.rootAttribute, r.conditions(), module.ctx)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		for _, instance := range instances {
+			instance.module = module
+		}
+		resources = append(resources, instances...)
 	}
-
-	resources, diags := getSimpleResourcesWithAttributes(config.Module, r.GetResourceType(), r.GetAttributeName(), ctx)
-
-	return ctx, resources, diags
-}
-
-func getResourcesOfResourceTypeIncludingSpecifiedAttribute(module *terraform.Module, attributeName string, ctx *terraform.Evaluator) (*hclext.BodyContent, hcl.Diagnostics) {
-	resources, diags := module.PartialContent(&hclext.BodySchema{
-		Blocks: []hclext.BlockSchema{
-			{
-				Type:       "resource",
-				LabelNames: []string{"type", "name"},
-				Body: &hclext.BodySchema{
-					Attributes: []hclext.AttributeSchema{
-						{
-							Name:     attributeName,
-							Required: false,
-						},
-					},
-				},
-			},
-		},
-	}, ctx)
-
-	return resources, diags
-}
-
-func getResourcesOfResourceTypeIncludingBlocksWithSpecifiedAttribute(module *terraform.Module, nestedBlockType string, attributeName string, ctx *terraform.Evaluator) (*hclext.BodyContent, hcl.Diagnostics) {
-	resources, diags := module.PartialContent(&hclext.BodySchema{
-		Blocks: []hclext.BlockSchema{
-			{
-				Type:       "resource",
-				LabelNames: []string{"type", "name"},
-				Body: &hclext.BodySchema{
-					Blocks: []hclext.BlockSchema{
-						{
-							Type: nestedBlockType,
-							Body: &hclext.BodySchema{
-								Attributes: []hclext.AttributeSchema{
-									{
-										Name:     attributeName,
-										Required: false,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}, ctx)
-
-	return resources, diags
+	return resources, nil
 }
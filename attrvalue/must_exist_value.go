@@ -0,0 +1,53 @@
+package attrvalue
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MustExistRule checks whether an attribute is specified, whatever its value.
+type MustExistRule struct {
+	tflint.DefaultRule // Embed the default rule to reuse its implementation
+	baseValue
+	ruleName string
+}
+
+var _ tflint.Rule = (*MustExistRule)(nil)
+var _ AttrValueRule = (*MustExistRule)(nil)
+
+// NewMustExistRule returns a new rule with the given resource type and attribute name.
+func NewMustExistRule(resourceType, attributeName, link string, ruleName string) *MustExistRule {
+	return &MustExistRule{
+		baseValue: newBaseValue(resourceType, nil, attributeName, true, link, tflint.ERROR),
+		ruleName:  ruleName,
+	}
+}
+
+// NewMustExistNestedBlockRule returns a new rule with the given resource type, nested block type, and attribute name.
+func NewMustExistNestedBlockRule(resourceType, nestedBlockType, attributeName, link string, ruleName string) *MustExistRule {
+	return &MustExistRule{
+		baseValue: newBaseValue(resourceType, &nestedBlockType, attributeName, true, link, tflint.ERROR),
+		ruleName:  ruleName,
+	}
+}
+
+func (r *MustExistRule) Link() string {
+	return r.link
+}
+
+func (r *MustExistRule) Name() string {
+	if r.ruleName != "" {
+		return r.ruleName
+	}
+
+	if r.nestedBlockType != nil {
+		return fmt.Sprintf("%s.%s.%s", r.resourceType, *r.nestedBlockType, r.attributeName)
+	}
+	return fmt.Sprintf("%s.%s", r.resourceType, r.attributeName)
+}
+
+func (r *MustExistRule) Check(runner tflint.Runner) error {
+	_, err := r.checkAttributeExists(runner, r)
+	return err
+}
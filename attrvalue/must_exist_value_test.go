@@ -0,0 +1,95 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestMustExistRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "attribute specified",
+			rule: attrvalue.NewMustExistRule("foo", "bar", "", ""),
+			content: `
+	resource "foo" "example" {
+		bar = "anything"
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "attribute from unknown variable",
+			rule: attrvalue.NewMustExistRule("foo", "bar", "", ""),
+			content: `
+	variable "bar" {
+		type = string
+	}
+	resource "foo" "example" {
+		bar = var.bar
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "attribute not specified",
+			rule: attrvalue.NewMustExistRule("foo", "bar", "", ""),
+			content: `
+	resource "foo" "example" {
+		baz = "anything"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewMustExistRule("foo", "bar", "", ""),
+					Message: "The attribute `bar` must be specified",
+				},
+			},
+		},
+		{
+			name: "nested block attribute not specified",
+			rule: attrvalue.NewMustExistNestedBlockRule("foo", "fiz", "bar", "", ""),
+			content: `
+	resource "foo" "example" {
+		fiz {
+			baz = "anything"
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewMustExistNestedBlockRule("foo", "fiz", "bar", "", ""),
+					Message: "The attribute `bar` must be specified",
+				},
+			},
+		},
+		{
+			name: "other resource type",
+			rule: attrvalue.NewMustExistRule("foo", "bar", "", ""),
+			content: `
+	resource "other" "example" {
+		baz = "anything"
+	}`,
+			expected: helper.Issues{},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
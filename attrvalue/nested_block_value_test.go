@@ -50,7 +50,7 @@ func TestNestedBlockValueRule(t *testing.T) {
 			expected: helper.Issues{
 				{
 					Rule:    attrvalue.NewSimpleNestedBlockRule("foo", "fiz", "bar", []string{"biz", "bat"}, "", false, ""),
-					Message: "baz is an invalid attribute value of `bar` - expecting (one of) [biz bat]",
+					Message: "`var.test (default) -> foo.example.fiz.bar`: baz is an invalid attribute value of `bar` - expecting (one of) [biz bat]",
 				},
 			},
 		},
@@ -165,7 +165,148 @@ func TestNestedBlockValueRule(t *testing.T) {
 			expected: helper.Issues{
 				{
 					Rule:    attrvalue.NewSimpleNestedBlockRule("foo", "fiz", "bar", []string{"biz", "bat"}, "", false, ""),
-					Message: "incorrect is an invalid attribute value of `bar` - expecting (one of) [biz bat]",
+					Message: "`var.test2 (default) -> foo.example.fiz.bar`: incorrect is an invalid attribute value of `bar` - expecting (one of) [biz bat]",
+				},
+			},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
+
+func TestBlockPathValueRule(t *testing.T) {
+	const path = "default_node_pool.upgrade_settings"
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "simple rule correct",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", path, "max_surge", []string{"33%"}, "", true, ""),
+			content: `
+	resource "foo" "example" {
+		default_node_pool {
+			upgrade_settings {
+				max_surge = "33%"
+			}
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "simple rule incorrect",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", path, "max_surge", []string{"33%"}, "", false, ""),
+			content: `
+	variable "max_surge" {
+		type    = string
+		default = "10%"
+	}
+	resource "foo" "example" {
+		default_node_pool {
+			upgrade_settings {
+				max_surge = var.max_surge
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleNestedBlockRule("foo", path, "max_surge", []string{"33%"}, "", false, ""),
+					Message: "`var.max_surge (default) -> foo.example.default_node_pool.upgrade_settings.max_surge`: 10% is an invalid attribute value of `max_surge` - expecting (one of) [33%]",
+				},
+			},
+		},
+		{
+			name: "attribute in a block of the same type at another level is ignored",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", path, "max_surge", []string{"33%"}, "", false, ""),
+			content: `
+	resource "foo" "example" {
+		upgrade_settings {
+			max_surge = "10%"
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "must exist with the intermediate block missing",
+			rule: attrvalue.NewSimpleNestedBlockRule("foo", path, "max_surge", []string{"33%"}, "", true, ""),
+			content: `
+	resource "foo" "example" {
+		default_node_pool {
+			name = "default"
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleNestedBlockRule("foo", path, "max_surge", []string{"33%"}, "", true, ""),
+					Message: "The attribute `max_surge` must be specified",
+				},
+			},
+		},
+		{
+			name: "must exist with the attribute missing in the innermost block",
+			rule: attrvalue.NewMustExistNestedBlockRule("foo", path, "max_surge", "", ""),
+			content: `
+	resource "foo" "example" {
+		default_node_pool {
+			upgrade_settings {
+				drain_timeout_in_minutes = 30
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewMustExistNestedBlockRule("foo", path, "max_surge", "", ""),
+					Message: "The attribute `max_surge` must be specified",
+				},
+			},
+		},
+		{
+			name: "set rule incorrect",
+			rule: attrvalue.NewSetNestedBlockRule("foo", "site_config.ip_restriction", "service_tags", [][]string{{"AzureFrontDoor.Backend"}}, "", ""),
+			content: `
+	resource "foo" "example" {
+		site_config {
+			ip_restriction {
+				service_tags = ["AzureCloud"]
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSetNestedBlockRule("foo", "site_config.ip_restriction", "service_tags", [][]string{{"AzureFrontDoor.Backend"}}, "", ""),
+					Message: "\"[AzureCloud]\" is an invalid attribute value of `service_tags` - expecting (one of) [[AzureFrontDoor.Backend]]",
+				},
+			},
+		},
+		{
+			name: "unknown value rule incorrect",
+			rule: attrvalue.NewUnknownValueNestedBlockRule("foo", "site_config.application_stack", "dotnet_version", "", ""),
+			content: `
+	resource "foo" "example" {
+		site_config {
+			application_stack {
+				dotnet_version = "v8.0"
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewUnknownValueNestedBlockRule("foo", "site_config.application_stack", "dotnet_version", "", ""),
+					Message: "invalid attribute value of `dotnet_version` - expecting unknown",
 				},
 			},
 		},
@@ -0,0 +1,112 @@
+package attrvalue
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// The kinds of block a NotAllowedRule can report, resources and data sources, and the kinds of block an attribute value rule can check,
+// which include module calls, see On.
+const (
+	BlockTypeResource = "resource"
+	BlockTypeData     = "data"
+	BlockTypeModule   = "module"
+)
+
+// NotAllowedRule reports every block of a resource type that must not be used, e.g. a deprecated resource,
+// whatever its attributes.
+type NotAllowedRule struct {
+	tflint.DefaultRule          // Embed the default rule to reuse its implementation
+	resourceType       string   // e.g. "azurerm_virtual_machine"
+	blockTypes         []string // BlockTypeResource and/or BlockTypeData
+	recommendation     string   // e.g. "azurerm_linux_virtual_machine", empty if there is no replacement
+	link               string
+	ruleName           string
+}
+
+var _ tflint.Rule = (*NotAllowedRule)(nil)
+
+// NewNotAllowedRule returns a new rule reporting the resource and data blocks of the given resource type.
+// When no block types are given, both resources and data sources are reported.
+func NewNotAllowedRule(resourceType string, blockTypes []string, recommendation, link, ruleName string) *NotAllowedRule {
+	if len(blockTypes) == 0 {
+		blockTypes = []string{BlockTypeResource, BlockTypeData}
+	}
+	return &NotAllowedRule{
+		resourceType:   resourceType,
+		blockTypes:     blockTypes,
+		recommendation: recommendation,
+		link:           link,
+		ruleName:       ruleName,
+	}
+}
+
+func (r *NotAllowedRule) Name() string {
+	if r.ruleName != "" {
+		return r.ruleName
+	}
+	return fmt.Sprintf("%s_not_allowed", r.resourceType)
+}
+
+func (r *NotAllowedRule) Link() string {
+	return r.link
+}
+
+func (r *NotAllowedRule) Enabled() bool {
+	return true
+}
+
+func (r *NotAllowedRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// GetResourceType returns the resource type that must not be used.
+func (r *NotAllowedRule) GetResourceType() string {
+	return r.resourceType
+}
+
+// GetBlockTypes returns the kinds of block that are reported.
+func (r *NotAllowedRule) GetBlockTypes() []string {
+	return slices.Clone(r.blockTypes)
+}
+
+// GetRecommendation returns the resource type to use instead.
+func (r *NotAllowedRule) GetRecommendation() string {
+	return r.recommendation
+}
+
+func (r *NotAllowedRule) Check(runner tflint.Runner) error {
+	schema := &hclext.BodySchema{}
+	for _, blockType := range r.blockTypes {
+		schema.Blocks = append(schema.Blocks, hclext.BlockSchema{
+			Type:       blockType,
+			LabelNames: []string{"type", "name"},
+			Body:       &hclext.BodySchema{},
+		})
+	}
+	content, err := runner.GetModuleContent(schema, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	for _, block := range content.Blocks {
+		if block.Labels[0] != r.resourceType {
+			continue
+		}
+		kind := "resource"
+		if block.Type == BlockTypeData {
+			kind = "data source"
+		}
+		message := fmt.Sprintf("The %s type `%s` is not allowed", kind, r.resourceType)
+		if r.recommendation != "" {
+			message += fmt.Sprintf(" - use %s instead", r.recommendation)
+		}
+		if err := runner.EmitIssue(r, message, block.DefRange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
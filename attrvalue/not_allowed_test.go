@@ -0,0 +1,80 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestNotAllowedRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "other resource type",
+			rule: attrvalue.NewNotAllowedRule("foo", nil, "bar", "", ""),
+			content: `
+	resource "bar" "example" {
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "resource of the type",
+			rule: attrvalue.NewNotAllowedRule("foo", nil, "bar", "", ""),
+			content: `
+	resource "foo" "example" {
+		name = "example"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewNotAllowedRule("foo", nil, "bar", "", ""),
+					Message: "The resource type `foo` is not allowed - use bar instead",
+				},
+			},
+		},
+		{
+			name: "empty resource and data source of the type",
+			rule: attrvalue.NewNotAllowedRule("foo", nil, "", "", ""),
+			content: `
+	resource "foo" "example" {
+	}
+	data "foo" "example" {
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewNotAllowedRule("foo", nil, "", "", ""),
+					Message: "The resource type `foo` is not allowed",
+				},
+				{
+					Rule:    attrvalue.NewNotAllowedRule("foo", nil, "", "", ""),
+					Message: "The data source type `foo` is not allowed",
+				},
+			},
+		},
+		{
+			name: "data source when only resources are reported",
+			rule: attrvalue.NewNotAllowedRule("foo", []string{attrvalue.BlockTypeResource}, "bar", "", ""),
+			content: `
+	data "foo" "example" {
+	}`,
+			expected: helper.Issues{},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package attrvalue
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// PatternRule checks whether a string attribute value matches a regular expression,
+// e.g. for naming conventions, TLS version strings or the shape of resource IDs.
+type PatternRule struct {
+	tflint.DefaultRule // Embed the default rule to reuse its implementation
+	baseValue
+	pattern   *regexp.Regexp // e.g. `^/subscriptions/[^/]+/resourceGroups/`
+	mustExist bool
+	ruleName  string
+}
+
+var _ tflint.Rule = (*PatternRule)(nil)
+var _ AttrValueRule = (*PatternRule)(nil)
+
+// NewPatternRule returns a new rule with the given resource type, attribute name, and pattern.
+// It panics if the pattern is not a valid regular expression.
+func NewPatternRule(resourceType, attributeName, pattern, link string, mustExist bool, ruleName string) *PatternRule {
+	return &PatternRule{
+		baseValue: newBaseValue(resourceType, nil, attributeName, true, link, tflint.ERROR),
+		pattern:   regexp.MustCompile(pattern),
+		mustExist: mustExist,
+		ruleName:  ruleName,
+	}
+}
+
+// NewPatternNestedBlockRule returns a new rule with the given resource type, nested block type, attribute name, and pattern.
+// It panics if the pattern is not a valid regular expression.
+func NewPatternNestedBlockRule(resourceType, nestedBlockType, attributeName, pattern, link string, mustExist bool, ruleName string) *PatternRule {
+	return &PatternRule{
+		baseValue: newBaseValue(resourceType, &nestedBlockType, attributeName, true, link, tflint.ERROR),
+		pattern:   regexp.MustCompile(pattern),
+		mustExist: mustExist,
+		ruleName:  ruleName,
+	}
+}
+
+func (r *PatternRule) Link() string {
+	return r.link
+}
+
+// GetPattern returns the regular expression the attribute value must match.
+func (r *PatternRule) GetPattern() string {
+	return r.pattern.String()
+}
+
+func (r *PatternRule) Name() string {
+	if r.ruleName != "" {
+		return r.ruleName
+	}
+
+	if r.nestedBlockType != nil {
+		return fmt.Sprintf("%s.%s.%s", r.resourceType, *r.nestedBlockType, r.attributeName)
+	}
+	return fmt.Sprintf("%s.%s", r.resourceType, r.attributeName)
+}
+
+func (r *PatternRule) Check(runner tflint.Runner) error {
+	if r.mustExist {
+		if missing, err := r.checkAttributeExists(runner, r); err != nil || missing {
+			return err
+		}
+	}
+
+	// The value is converted in each instance, so that a value of another type is reported rather than failing the check.
+	return r.checkValues(runner, r, cty.DynamicPseudoType, func(runner tflint.Runner, attr *hclext.Attribute, val cty.Value) error {
+		if val.IsNull() || !val.IsKnown() {
+			return nil
+		}
+		str, err := convert.Convert(val, cty.String)
+		if err != nil {
+			return runner.EmitIssue(r, wrongTypeMessage(r.attributeName, val, "a string"), attr.Range)
+		}
+		if r.pattern.MatchString(str.AsString()) {
+			return nil
+		}
+		return runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s is an invalid attribute value of `%s` - expecting a value matching `%s`", str.AsString(), r.attributeName, r.pattern),
+			attr.Range,
+		)
+	})
+}
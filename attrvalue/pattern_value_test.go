@@ -0,0 +1,161 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestPatternValueRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "matching value",
+			rule: attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", false, ""),
+			content: `
+	variable "test" {
+		type    = string
+		default = "TLS1_2"
+	}
+	resource "foo" "example" {
+		bar = var.test
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "value not matching",
+			rule: attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = "TLS1_0"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", false, ""),
+					Message: "TLS1_0 is an invalid attribute value of `bar` - expecting a value matching `^TLS1_[23]$`",
+				},
+			},
+		},
+		{
+			name: "number converted to string",
+			rule: attrvalue.NewPatternRule("foo", "bar", "^[0-9]+$", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = 42
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "value of another type in one instance",
+			rule: attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = ["TLS1_2"]
+	}
+	resource "foo" "example2" {
+		bar = "TLS1_0"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", false, ""),
+					Message: "a value of type tuple is an invalid attribute value of `bar` - expecting a string",
+				},
+				{
+					Rule:    attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", false, ""),
+					Message: "TLS1_0 is an invalid attribute value of `bar` - expecting a value matching `^TLS1_[23]$`",
+				},
+			},
+		},
+		{
+			name: "unknown value",
+			rule: attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", false, ""),
+			content: `
+	variable "test" {
+		type = string
+	}
+	resource "foo" "example" {
+		bar = var.test
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "null value",
+			rule: attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = null
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "missing attribute",
+			rule: attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", false, ""),
+			content: `
+	resource "foo" "example" {
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "missing attribute with must exist set",
+			rule: attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", true, ""),
+			content: `
+	resource "foo" "example" {
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewPatternRule("foo", "bar", "^TLS1_[23]$", "", true, ""),
+					Message: "The attribute `bar` must be specified",
+				},
+			},
+		},
+		{
+			name: "nested block value matching",
+			rule: attrvalue.NewPatternNestedBlockRule("foo", "fiz", "bar", "^/subscriptions/", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		fiz {
+			bar = "/subscriptions/0000/resourceGroups/rg"
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "nested block value not matching",
+			rule: attrvalue.NewPatternNestedBlockRule("foo", "fiz", "bar", "^/subscriptions/", "", false, ""),
+			content: `
+	resource "foo" "example" {
+		fiz {
+			bar = "rg"
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewPatternNestedBlockRule("foo", "fiz", "bar", "^/subscriptions/", "", false, ""),
+					Message: "rg is an invalid attribute value of `bar` - expecting a value matching `^/subscriptions/`",
+				},
+			},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package attrvalue
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// origin is the expression an attribute value comes from, when the attribute refers to a variable or a local value.
+type origin struct {
+	rng   hcl.Range
+	chain []string // The references from the origin to the attribute, e.g. ["var.sku (default)", "local.sku"]
+}
+
+// traceOrigin returns the origin of the value of the expression in the module, nil if the expression does not refer
+// to a single variable or local value, e.g. a literal value or a function call.
+// A variable of the root module gets its value from its default, or from the default of an optional() attribute of its type.
+// The variables of a called module are bound to the module call arguments, see moduleCallRunner.
+func traceOrigin(module *moduleInstance, expr hcl.Expression) *origin {
+	traversal, ok := referenceOf(expr)
+	if !ok || len(traversal) < 2 {
+		return nil
+	}
+	name, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return nil
+	}
+	path := traversal[2:]
+	reference := traversalString(traversal)
+
+	switch traversal.RootName() {
+	case "var":
+		if module.caller != nil {
+			return nil
+		}
+		return variableOrigin(module, name.Name, path, reference)
+	case "local":
+		local, ok := module.config.Module.Locals[name.Name]
+		if !ok {
+			return nil
+		}
+		if localRef, ok := referenceOf(local.Expr); ok {
+			next := traceOrigin(module, &hclsyntax.ScopeTraversalExpr{
+				Traversal: append(append(hcl.Traversal{}, localRef...), path...),
+				SrcRange:  local.Expr.Range(),
+			})
+			if next != nil {
+				next.chain = append(next.chain, reference)
+				return next
+			}
+		}
+		return &origin{rng: local.DeclRange, chain: []string{reference}}
+	}
+	return nil
+}
+
+// variableOrigin returns the origin of the value at the path inside the variable of the root module, nil if the variable has no default.
+func variableOrigin(module *moduleInstance, name string, path hcl.Traversal, reference string) *origin {
+	variable := variableBlock(module, name)
+	if variable == nil {
+		return nil
+	}
+	def, ok := variable.Body.Attributes["default"]
+	if !ok {
+		return nil
+	}
+	if typ, ok := variable.Body.Attributes["type"]; ok && len(path) > 0 {
+		val, diags := def.Expr.Value(nil)
+		if !diags.HasErrors() && valueAtPath(val, path).IsNull() {
+			if rng, ok := optionalDefaultRange(typ.Expr, path); ok {
+				return &origin{rng: rng, chain: []string{reference + " (optional default)"}}
+			}
+		}
+	}
+	return &origin{rng: def.Range, chain: []string{reference + " (default)"}}
+}
+
+// variableBlock returns the block of the named variable of the module, with its default, type and validation conditions.
+// It returns nil if the module has no such variable.
+func variableBlock(module *moduleInstance, name string) *hclext.Block {
+	variables, diags := module.config.Module.PartialContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "default"}, {Name: "type"}},
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "validation",
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "condition"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil) // The type constraint is not an expression that can be evaluated
+	if diags.HasErrors() {
+		return nil
+	}
+	for _, variable := range variables.Blocks {
+		if variable.Labels[0] == name {
+			return variable
+		}
+	}
+	return nil
+}
+
+// optionalDefaultRange returns the range of the optional() attribute with a default in the type constraint
+// that gives the value at the path, e.g. `optional(string, "Standard")` for `.sku` in `object({ sku = optional(string, "Standard") })`.
+func optionalDefaultRange(typeExpr hcl.Expression, path hcl.Traversal) (hcl.Range, bool) {
+	var rng hcl.Range
+	found := false
+	expr := typeExpr
+	for _, step := range path {
+		call, ok := hcl.UnwrapExpression(expr).(*hclsyntax.FunctionCallExpr)
+		if ok && call.Name == "optional" && len(call.Args) > 0 {
+			expr = call.Args[0]
+			call, ok = hcl.UnwrapExpression(expr).(*hclsyntax.FunctionCallExpr)
+		}
+		if !ok || call.Name != "object" || len(call.Args) != 1 {
+			break
+		}
+		attributes, ok := call.Args[0].(*hclsyntax.ObjectConsExpr)
+		if !ok {
+			break
+		}
+		key := stepKey(step)
+		expr = nil
+		for _, item := range attributes.Items {
+			if hcl.ExprAsKeyword(item.KeyExpr) == key {
+				expr = item.ValueExpr
+			}
+		}
+		if expr == nil {
+			break
+		}
+		if call, ok := hcl.UnwrapExpression(expr).(*hclsyntax.FunctionCallExpr); ok && call.Name == "optional" && len(call.Args) == 2 {
+			rng, found = call.Range(), true
+		}
+	}
+	return rng, found
+}
+
+// stepKey returns the attribute name or the string key of a traversal step, an empty string for other steps.
+func stepKey(step hcl.Traverser) string {
+	switch s := step.(type) {
+	case hcl.TraverseAttr:
+		return s.Name
+	case hcl.TraverseIndex:
+		if s.Key.Type() == cty.String {
+			return s.Key.AsString()
+		}
+	}
+	return ""
+}
+
+// referenceOf returns the traversal of an expression that is a single reference, e.g. `var.sku` or `"${local.sku}"`.
+func referenceOf(expr hcl.Expression) (hcl.Traversal, bool) {
+	switch e := hcl.UnwrapExpression(expr).(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		return e.Traversal, true
+	case *hclsyntax.TemplateWrapExpr:
+		return referenceOf(e.Wrapped)
+	}
+	return nil, false
+}
+
+// traversalString returns the traversal as it is written, e.g. `var.settings.sku` or `local.skus["lb"]`.
+func traversalString(traversal hcl.Traversal) string {
+	var b strings.Builder
+	b.WriteString(traversal.RootName())
+	for _, step := range traversal[1:] {
+		switch s := step.(type) {
+		case hcl.TraverseAttr:
+			fmt.Fprintf(&b, ".%s", s.Name)
+		case hcl.TraverseIndex:
+			b.WriteString(instanceAddress("", s.Key))
+		case hcl.TraverseSplat:
+			b.WriteString("[*]")
+		}
+	}
+	return b.String()
+}
+
+var _ tflint.Runner = new(originRunner)
+
+// originRunner reports the issues found in an attribute at the origin of its value,
+// and prefixes their messages with the references from the origin to the attribute, e.g. `var.sku (default) -> azurerm_lb.this.sku`.
+// With issues set, the issues are gathered until the instances of the resource are all checked, see originIssues.
+type originRunner struct {
+	tflint.Runner
+	origin *origin
+	issues *originIssues
+}
+
+func (r *originRunner) EmitIssue(rule tflint.Rule, message string, _ hcl.Range) error {
+	if r.issues != nil {
+		r.issues.add(r.Runner, rule, message, r.origin)
+		return nil
+	}
+	return r.Runner.EmitIssue(rule, fmt.Sprintf("`%s`: %s", strings.Join(r.origin.chain, " -> "), message), r.origin.rng)
+}
+
+// originIssues gathers the issues reported at the origin of the attribute values of the checked resource instances,
+// so that an origin shared by several instances, e.g. the default of a variable used by a resource with count, is reported once
+// with the attributes of all the instances, e.g. `var.sku (default) -> azurerm_lb.this[0].sku, azurerm_lb.this[1].sku`.
+type originIssues struct {
+	issues []*originIssue
+}
+
+type originIssue struct {
+	runner     tflint.Runner
+	rule       tflint.Rule
+	message    string
+	rng        hcl.Range
+	references []string // The references from the origin, without the attribute
+	attributes []string // e.g. ["azurerm_lb.this[0].sku", "azurerm_lb.this[1].sku"]
+}
+
+// add gathers the issue found at the origin, merged with an issue of the same rule and message found at the same origin through the same references.
+func (o *originIssues) add(runner tflint.Runner, rule tflint.Rule, message string, origin *origin) {
+	references := origin.chain[:len(origin.chain)-1]
+	attribute := origin.chain[len(origin.chain)-1]
+	for _, issue := range o.issues {
+		if issue.rule.Name() != rule.Name() || issue.message != message || issue.rng != origin.rng || !slices.Equal(issue.references, references) {
+			continue
+		}
+		if !slices.Contains(issue.attributes, attribute) {
+			issue.attributes = append(issue.attributes, attribute)
+		}
+		return
+	}
+	o.issues = append(o.issues, &originIssue{
+		runner:     runner,
+		rule:       rule,
+		message:    message,
+		rng:        origin.rng,
+		references: references,
+		attributes: []string{attribute},
+	})
+}
+
+// emit reports the gathered issues, in the order they were first found.
+func (o *originIssues) emit() error {
+	for _, issue := range o.issues {
+		chain := strings.Join(append(append([]string{}, issue.references...), strings.Join(issue.attributes, ", ")), " -> ")
+		if err := issue.runner.EmitIssue(issue.rule, fmt.Sprintf("`%s`: %s", chain, issue.message), issue.rng); err != nil {
+			return err
+		}
+	}
+	return nil
+}
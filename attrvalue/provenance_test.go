@@ -0,0 +1,162 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func TestValueOrigin(t *testing.T) {
+	testCases := []struct {
+		name    string
+		files   map[string]string
+		message string
+		start   hcl.Pos
+	}{
+		{
+			name: "variable default",
+			files: map[string]string{
+				"main.tf": `variable "sku" {
+  type    = string
+  default = "Basic"
+}
+resource "foo" "this" {
+  sku = var.sku
+}`,
+			},
+			message: "`var.sku (default) -> foo.this.sku`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+			start:   hcl.Pos{Line: 3, Column: 3},
+		},
+		{
+			name: "variable default used by a resource with count",
+			files: map[string]string{
+				"main.tf": `variable "sku" {
+  type    = string
+  default = "Basic"
+}
+resource "foo" "this" {
+  count = 3
+  sku   = var.sku
+}`,
+			},
+			message: "`var.sku (default) -> foo.this[0].sku, foo.this[1].sku, foo.this[2].sku`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+			start:   hcl.Pos{Line: 3, Column: 3},
+		},
+		{
+			name: "local value referring to a variable",
+			files: map[string]string{
+				"main.tf": `variable "skus" {
+  type    = map(string)
+  default = { lb = "Basic" }
+}
+locals {
+  skus = var.skus
+}
+resource "foo" "this" {
+  sku = "${local.skus["lb"]}"
+}`,
+			},
+			message: "`var.skus[\"lb\"] (default) -> local.skus[\"lb\"] -> foo.this.sku`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+			start:   hcl.Pos{Line: 3, Column: 3},
+		},
+		{
+			name: "local value",
+			files: map[string]string{
+				"main.tf": `locals {
+  sku = "Basic"
+}
+resource "foo" "this" {
+  sku = local.sku
+}`,
+			},
+			message: "`local.sku -> foo.this.sku`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+			start:   hcl.Pos{Line: 2, Column: 3},
+		},
+		{
+			name: "optional attribute default",
+			files: map[string]string{
+				"main.tf": `variable "lb" {
+  type = object({
+    sku = optional(string, "Basic")
+  })
+  default = {}
+}
+resource "foo" "this" {
+  sku = var.lb.sku
+}`,
+			},
+			message: "`var.lb.sku (optional default) -> foo.this.sku`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+			start:   hcl.Pos{Line: 3, Column: 11},
+		},
+		{
+			name: "optional attribute set in the variable default",
+			files: map[string]string{
+				"main.tf": `variable "lb" {
+  type = object({
+    sku = optional(string, "Standard")
+  })
+  default = { sku = "Basic" }
+}
+resource "foo" "this" {
+  sku = var.lb.sku
+}`,
+			},
+			message: "`var.lb.sku (default) -> foo.this.sku`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+			start:   hcl.Pos{Line: 5, Column: 3},
+		},
+		{
+			name: "expression",
+			files: map[string]string{
+				"main.tf": `variable "sku" {
+  type    = string
+  default = "basic"
+}
+resource "foo" "this" {
+  sku = title(var.sku)
+}`,
+			},
+			message: "Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+			start:   hcl.Pos{Line: 6, Column: 3},
+		},
+		{
+			name: "module call argument",
+			files: map[string]string{
+				"main.tf": `variable "lb_sku" {
+  type    = string
+  default = "Basic"
+}
+module "lb" {
+  source = "./modules/lb"
+  sku    = var.lb_sku
+}`,
+				"modules/lb/main.tf": lbModule,
+			},
+			message: "`var.lb_sku (default) -> module.lb.sku -> module.lb.foo.this.sku`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+			start:   hcl.Pos{Line: 3, Column: 3},
+		},
+	}
+
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.files["main.tf"]})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFiles(tc.files))
+			defer stub.Reset()
+			rule := attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, "")
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if assert.Len(t, runner.Issues, 1) {
+				assert.Equal(t, tc.message, runner.Issues[0].Message)
+				assert.Equal(t, "main.tf", runner.Issues[0].Range.Filename)
+				assert.Equal(t, tc.start.Line, runner.Issues[0].Range.Start.Line)
+				assert.Equal(t, tc.start.Column, runner.Issues[0].Range.Start.Column)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package attrvalue
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// Bound is the lower or upper bound of a RangeRule.
+type Bound struct {
+	Value     float64
+	Exclusive bool // The bound itself is not allowed, e.g. `> 0` rather than `>= 0`.
+}
+
+// Inclusive returns a bound that allows the value itself.
+func Inclusive(v float64) *Bound {
+	return &Bound{Value: v}
+}
+
+// Exclusive returns a bound that does not allow the value itself.
+func Exclusive(v float64) *Bound {
+	return &Bound{Value: v, Exclusive: true}
+}
+
+// NewBound returns the bound at the given value, or nil if there is no value, e.g. when it is optional in a config.
+func NewBound(value *float64, exclusive bool) *Bound {
+	if value == nil {
+		return nil
+	}
+	return &Bound{Value: *value, Exclusive: exclusive}
+}
+
+func (b Bound) String() string {
+	return strconv.FormatFloat(b.Value, 'f', -1, 64)
+}
+
+// RangeRule checks whether a number attribute value is within a range,
+// e.g. a backup retention of at least 7 days or a node count of at least 2.
+type RangeRule struct {
+	tflint.DefaultRule // Embed the default rule to reuse its implementation
+	baseValue
+	min       *Bound // nil when there is no lower bound
+	max       *Bound // nil when there is no upper bound
+	mustExist bool
+	ruleName  string
+}
+
+var _ tflint.Rule = (*RangeRule)(nil)
+var _ AttrValueRule = (*RangeRule)(nil)
+
+// NewRangeRule returns a new rule with the given resource type, attribute name, and bounds.
+// Either bound can be nil.
+func NewRangeRule(resourceType, attributeName string, min, max *Bound, link string, mustExist bool, ruleName string) *RangeRule {
+	return &RangeRule{
+		baseValue: newBaseValue(resourceType, nil, attributeName, true, link, tflint.ERROR),
+		min:       min,
+		max:       max,
+		mustExist: mustExist,
+		ruleName:  ruleName,
+	}
+}
+
+// NewRangeNestedBlockRule returns a new rule with the given resource type, nested block type, attribute name, and bounds.
+// Either bound can be nil.
+func NewRangeNestedBlockRule(resourceType, nestedBlockType, attributeName string, min, max *Bound, link string, mustExist bool, ruleName string) *RangeRule {
+	return &RangeRule{
+		baseValue: newBaseValue(resourceType, &nestedBlockType, attributeName, true, link, tflint.ERROR),
+		min:       min,
+		max:       max,
+		mustExist: mustExist,
+		ruleName:  ruleName,
+	}
+}
+
+func (r *RangeRule) Link() string {
+	return r.link
+}
+
+// GetBounds returns the lower and upper bounds of the range, nil when unbounded.
+func (r *RangeRule) GetBounds() (min, max *Bound) {
+	return r.min, r.max
+}
+
+// Describe returns the range in a readable form, e.g. `>= 7 and < 30`.
+func (r *RangeRule) Describe() string {
+	var parts []string
+	if r.min != nil {
+		parts = append(parts, minDescription(*r.min))
+	}
+	if r.max != nil {
+		parts = append(parts, maxDescription(*r.max))
+	}
+	return strings.Join(parts, " and ")
+}
+
+func (r *RangeRule) Name() string {
+	if r.ruleName != "" {
+		return r.ruleName
+	}
+
+	if r.nestedBlockType != nil {
+		return fmt.Sprintf("%s.%s.%s", r.resourceType, *r.nestedBlockType, r.attributeName)
+	}
+	return fmt.Sprintf("%s.%s", r.resourceType, r.attributeName)
+}
+
+func (r *RangeRule) Check(runner tflint.Runner) error {
+	if r.mustExist {
+		if missing, err := r.checkAttributeExists(runner, r); err != nil || missing {
+			return err
+		}
+	}
+
+	// The value is converted in each instance, so that a value of another type is reported rather than failing the check.
+	return r.checkValues(runner, r, cty.DynamicPseudoType, func(runner tflint.Runner, attr *hclext.Attribute, val cty.Value) error {
+		if val.IsNull() || !val.IsKnown() {
+			return nil
+		}
+		num, err := convert.Convert(val, cty.Number)
+		if err != nil {
+			return runner.EmitIssue(r, wrongTypeMessage(r.attributeName, val, "a number"), attr.Range)
+		}
+		v := num.AsBigFloat()
+		var violated string
+		switch {
+		case r.min != nil && !aboveMin(v, *r.min):
+			violated = minDescription(*r.min)
+		case r.max != nil && !belowMax(v, *r.max):
+			violated = maxDescription(*r.max)
+		default:
+			return nil
+		}
+		return runner.EmitIssue(
+			r,
+			fmt.Sprintf("%s is an invalid attribute value of `%s` - expecting a value %s", v.Text('f', -1), r.attributeName, violated),
+			attr.Range,
+		)
+	})
+}
+
+func aboveMin(v *big.Float, min Bound) bool {
+	c := v.Cmp(big.NewFloat(min.Value))
+	return c > 0 || (c == 0 && !min.Exclusive)
+}
+
+func belowMax(v *big.Float, max Bound) bool {
+	c := v.Cmp(big.NewFloat(max.Value))
+	return c < 0 || (c == 0 && !max.Exclusive)
+}
+
+func minDescription(min Bound) string {
+	if min.Exclusive {
+		return "> " + min.String()
+	}
+	return ">= " + min.String()
+}
+
+func maxDescription(max Bound) string {
+	if max.Exclusive {
+		return "< " + max.String()
+	}
+	return "<= " + max.String()
+}
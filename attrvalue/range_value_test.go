@@ -0,0 +1,185 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestRangeValueRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "value within range",
+			rule: attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), attrvalue.Inclusive(30), "", false, ""),
+			content: `
+	variable "test" {
+		type    = number
+		default = 14
+	}
+	resource "foo" "example" {
+		bar = var.test
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "value on inclusive bounds",
+			rule: attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), attrvalue.Inclusive(30), "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = 7
+	}
+	resource "foo" "example2" {
+		bar = 30
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "value below minimum from variable default",
+			rule: attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), nil, "", false, ""),
+			content: `
+	variable "test" {
+		type    = number
+		default = 1
+	}
+	resource "foo" "example" {
+		bar = var.test
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), nil, "", false, ""),
+					Message: "`var.test (default) -> foo.example.bar`: 1 is an invalid attribute value of `bar` - expecting a value >= 7",
+				},
+			},
+		},
+		{
+			name: "value on exclusive minimum",
+			rule: attrvalue.NewRangeRule("foo", "bar", attrvalue.Exclusive(0), nil, "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = 0
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRangeRule("foo", "bar", attrvalue.Exclusive(0), nil, "", false, ""),
+					Message: "0 is an invalid attribute value of `bar` - expecting a value > 0",
+				},
+			},
+		},
+		{
+			name: "value above maximum",
+			rule: attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), attrvalue.Exclusive(30), "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = 30
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), attrvalue.Exclusive(30), "", false, ""),
+					Message: "30 is an invalid attribute value of `bar` - expecting a value < 30",
+				},
+			},
+		},
+		{
+			name: "fractional value",
+			rule: attrvalue.NewRangeRule("foo", "bar", nil, attrvalue.Inclusive(1), "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = 1.5
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRangeRule("foo", "bar", nil, attrvalue.Inclusive(1), "", false, ""),
+					Message: "1.5 is an invalid attribute value of `bar` - expecting a value <= 1",
+				},
+			},
+		},
+		{
+			name: "unknown value",
+			rule: attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), nil, "", false, ""),
+			content: `
+	variable "test" {
+		type = number
+	}
+	resource "foo" "example" {
+		bar = var.test
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "missing attribute with must exist set",
+			rule: attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), nil, "", true, ""),
+			content: `
+	resource "foo" "example" {
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), nil, "", true, ""),
+					Message: "The attribute `bar` must be specified",
+				},
+			},
+		},
+		{
+			name: "value of another type in one instance",
+			rule: attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), nil, "", false, ""),
+			content: `
+	resource "foo" "example" {
+		bar = "high"
+	}
+	resource "foo" "example2" {
+		bar = 3
+	}
+	resource "foo" "example3" {
+		bar = "10"
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), nil, "", false, ""),
+					Message: "\"high\" is an invalid attribute value of `bar` - expecting a number",
+				},
+				{
+					Rule:    attrvalue.NewRangeRule("foo", "bar", attrvalue.Inclusive(7), nil, "", false, ""),
+					Message: "3 is an invalid attribute value of `bar` - expecting a value >= 7",
+				},
+			},
+		},
+		{
+			name: "nested block value below minimum",
+			rule: attrvalue.NewRangeNestedBlockRule("foo", "fiz", "bar", attrvalue.Inclusive(2), nil, "", false, ""),
+			content: `
+	resource "foo" "example" {
+		fiz {
+			bar = 1
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRangeNestedBlockRule("foo", "fiz", "bar", attrvalue.Inclusive(2), nil, "", false, ""),
+					Message: "1 is an invalid attribute value of `bar` - expecting a value >= 2",
+				},
+			},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestResourceInstanceValueRule(t *testing.T) {
+	zonesWhenStandard := func() tflint.Rule {
+		condition, err := attrvalue.NewCondition("sku", cty.TupleVal([]cty.Value{cty.StringVal("Standard")}), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return attrvalue.When(attrvalue.NewSetRule("foo", "zones", [][]int{{1, 2, 3}}, "", ""), *condition)
+	}
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "for_each over a map",
+			rule: attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+			content: `
+	variable "pips" {
+		type = map(object({ sku = string }))
+		default = {
+			pip1 = { sku = "Basic" }
+			pip2 = { sku = "Standard" }
+		}
+	}
+	resource "foo" "this" {
+		for_each = var.pips
+		sku      = each.value.sku
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", false, ""),
+					Message: "`foo.this[\"pip1\"]`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
+				},
+			},
+		},
+		{
+			name: "for_each over a set",
+			rule: attrvalue.NewPatternRule("foo", "name", "^pip-", "", false, ""),
+			content: `
+	resource "foo" "this" {
+		for_each = toset(["pip-1", "ip-2"])
+		name     = each.key
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewPatternRule("foo", "name", "^pip-", "", false, ""),
+					Message: "`foo.this[\"ip-2\"]`: ip-2 is an invalid attribute value of `name` - expecting a value matching `^pip-`",
+				},
+			},
+		},
+		{
+			name: "count",
+			rule: attrvalue.NewRangeRule("foo", "retention_days", attrvalue.Inclusive(7), nil, "", false, ""),
+			content: `
+	variable "retention_days" {
+		type    = list(number)
+		default = [7, 3, 1]
+	}
+	resource "foo" "this" {
+		count          = length(var.retention_days)
+		retention_days = var.retention_days[count.index]
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewRangeRule("foo", "retention_days", attrvalue.Inclusive(7), nil, "", false, ""),
+					Message: "`foo.this[1]`: 3 is an invalid attribute value of `retention_days` - expecting a value >= 7",
+				},
+				{
+					Rule:    attrvalue.NewRangeRule("foo", "retention_days", attrvalue.Inclusive(7), nil, "", false, ""),
+					Message: "`foo.this[2]`: 1 is an invalid attribute value of `retention_days` - expecting a value >= 7",
+				},
+			},
+		},
+		{
+			name: "count of zero",
+			rule: attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", true, ""),
+			content: `
+	resource "foo" "this" {
+		count = 0
+		sku   = "Basic"
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "must exist in an instance",
+			rule: attrvalue.NewMustExistNestedBlockRule("foo", "backup", "type", "", ""),
+			content: `
+	variable "accounts" {
+		type = map(object({ backup = bool }))
+		default = {
+			account1 = { backup = true }
+			account2 = { backup = false }
+		}
+	}
+	resource "foo" "this" {
+		for_each = var.accounts
+		dynamic "backup" {
+			for_each = each.value.backup ? ["Continuous"] : []
+			content {
+				type = backup.value
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    attrvalue.NewMustExistNestedBlockRule("foo", "backup", "type", "", ""),
+					Message: "`foo.this[\"account2\"]`: The attribute `type` must be specified",
+				},
+			},
+		},
+		{
+			name: "condition evaluated per instance",
+			rule: zonesWhenStandard(),
+			content: `
+	variable "pips" {
+		type = map(object({ sku = string }))
+		default = {
+			pip1 = { sku = "Basic" }
+			pip2 = { sku = "Standard" }
+		}
+	}
+	resource "foo" "this" {
+		for_each = var.pips
+		sku      = each.value.sku
+		zones    = [1]
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    zonesWhenStandard(),
+					Message: "`foo.this[\"pip2\"]`: \"[1]\" is an invalid attribute value of `zones` - expecting (one of) [[1 2 3]]",
+				},
+			},
+		},
+		{
+			name: "unknown for_each",
+			rule: attrvalue.NewSimpleRule("foo", "sku", []string{"Standard"}, "", true, ""),
+			content: `
+	variable "pips" {
+		type = map(object({ sku = string }))
+	}
+	resource "foo" "this" {
+		for_each = var.pips
+		sku      = each.value.sku
+	}`,
+			expected: helper.Issues{},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
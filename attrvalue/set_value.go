@@ -20,7 +20,7 @@ type SetRule[T cmp.Ordered] struct {
 }
 
 var _ tflint.Rule = (*SetRule[int])(nil)
-var _ AttrValueRule = (*SimpleRule[any])(nil)
+var _ ExpectedValuesRule = (*SetRule[int])(nil)
 
 // NewSetRule returns a new rule with the given resource type, attribute name, and expected values.
 func NewSetRule[T cmp.Ordered](resourceType string, attributeName string, expectedValues [][]T, link string, ruleName string) *SetRule[T] {
@@ -31,6 +31,28 @@ func NewSetRule[T cmp.Ordered](resourceType string, attributeName string, expect
 	}
 }
 
+// NewSetNestedBlockRule returns a new rule with the given resource type, nested block type, attribute name, and expected values.
+func NewSetNestedBlockRule[T cmp.Ordered](resourceType, nestedBlockType, attributeName string, expectedValues [][]T, link string, ruleName string) *SetRule[T] {
+	return &SetRule[T]{
+		baseValue:      newBaseValue(resourceType, &nestedBlockType, attributeName, true, link, tflint.ERROR),
+		expectedValues: expectedValues,
+		ruleName:       ruleName,
+	}
+}
+
+func (r *SetRule[T]) Link() string {
+	return r.link
+}
+
+// GetExpectedValues returns the sets of values the attribute is allowed to have.
+func (r *SetRule[T]) GetExpectedValues() []any {
+	values := make([]any, 0, len(r.expectedValues))
+	for _, v := range r.expectedValues {
+		values = append(values, v)
+	}
+	return values
+}
+
 func (r *SetRule[T]) Name() string {
 	if r.ruleName != "" {
 		return r.ruleName
@@ -53,7 +75,7 @@ func (r *SetRule[T]) Check(runner tflint.Runner) error {
 	if err != nil {
 		return err
 	}
-	return r.checkAttributes(runner, ctyTypeS, func(attr *hclext.Attribute, val cty.Value) error {
+	return r.checkValues(runner, r, ctyTypeS, func(runner tflint.Runner, attr *hclext.Attribute, val cty.Value) error {
 		if val.IsNull() || !val.IsKnown() {
 			return nil
 		}
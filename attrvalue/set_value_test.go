@@ -31,7 +31,7 @@ func TestListNumberValueRule(t *testing.T) {
 			expected: helper.Issues{
 				{
 					Rule:    attrvalue.NewSetRule("foo", "bar", [][]int{{1, 2, 3}}, "", ""),
-					Message: "\"[3]\" is an invalid attribute value of `bar` - expecting (one of) [[1 2 3]]",
+					Message: "`var.test (default) -> foo.example.bar`: \"[3]\" is an invalid attribute value of `bar` - expecting (one of) [[1 2 3]]",
 				},
 			},
 		},
@@ -21,7 +21,7 @@ type SimpleRule[T any] struct {
 }
 
 var _ tflint.Rule = (*SimpleRule[any])(nil)
-var _ AttrValueRule = (*SimpleRule[any])(nil)
+var _ ExpectedValuesRule = (*SimpleRule[any])(nil)
 
 // NewSimpleRule returns a new rule with the given resource type, attribute name, and expected values.
 func NewSimpleRule[T any](resourceType, attributeName string, expectedValues []T, link string, mustExist bool, ruleName string) *SimpleRule[T] {
@@ -47,6 +47,15 @@ func (r *SimpleRule[T]) Link() string {
 	return r.link
 }
 
+// GetExpectedValues returns the values the attribute is allowed to have.
+func (r *SimpleRule[T]) GetExpectedValues() []any {
+	values := make([]any, 0, len(r.expectedValues))
+	for _, v := range r.expectedValues {
+		values = append(values, v)
+	}
+	return values
+}
+
 func (r *SimpleRule[T]) Name() string {
 	if r.ruleName != "" {
 		return r.ruleName
@@ -66,21 +75,12 @@ func (r *SimpleRule[T]) Check(runner tflint.Runner) error {
 	}
 
 	if r.mustExist {
-		exists, resource, err := r.attributeExistsWhereResourceIsSpecified(runner)
-		if err != nil {
+		if missing, err := r.checkAttributeExists(runner, r); err != nil || missing {
 			return err
 		}
-
-		if !exists {
-			return runner.EmitIssue(
-				r,
-				fmt.Sprintf("The attribute `%s` must be specified", r.attributeName),
-				resource.DefRange,
-			)
-		}
 	}
 
-	return r.checkAttributes(runner, cty.DynamicPseudoType, func(attr *hclext.Attribute, val cty.Value) error {
+	return r.checkValues(runner, r, cty.DynamicPseudoType, func(runner tflint.Runner, attr *hclext.Attribute, val cty.Value) error {
 		if val.IsNull() || !val.IsKnown() {
 			return nil
 		}
@@ -44,7 +44,7 @@ func TestSimpleValueRule(t *testing.T) {
 			expected: helper.Issues{
 				{
 					Rule:    attrvalue.NewSimpleRule("foo", "bar", []string{"bar", "bat"}, "", false, ""),
-					Message: "fiz is an invalid attribute value of `bar` - expecting (one of) [bar bat]",
+					Message: "`var.test (default) -> foo.example.bar`: fiz is an invalid attribute value of `bar` - expecting (one of) [bar bat]",
 				},
 			},
 		},
@@ -88,7 +88,7 @@ resource "foo" "example" {
 			expected: helper.Issues{
 				{
 					Rule:    attrvalue.NewSimpleRule("foo", "bar", []int{1, 2}, "", false, ""),
-					Message: "3 is an invalid attribute value of `bar` - expecting (one of) [1 2]",
+					Message: "`var.test (default) -> foo.example.bar`: 3 is an invalid attribute value of `bar` - expecting (one of) [1 2]",
 				},
 			},
 		},
@@ -106,7 +106,7 @@ resource "foo" "example" {
 			expected: helper.Issues{
 				{
 					Rule:    attrvalue.NewSimpleRule("foo", "bar", []float64{1.1, 2.2}, "", false, ""),
-					Message: "2.1 is an invalid attribute value of `bar` - expecting (one of) [1.1 2.2]",
+					Message: "`var.test (default) -> foo.example.bar`: 2.1 is an invalid attribute value of `bar` - expecting (one of) [1.1 2.2]",
 				},
 			},
 		},
@@ -137,7 +137,7 @@ resource "foo" "example" {
 			expected: helper.Issues{
 				{
 					Rule:    attrvalue.NewSimpleRule("foo", "bar", []bool{true}, "", false, ""),
-					Message: "false is an invalid attribute value of `bar` - expecting (one of) [true]",
+					Message: "`var.test (default) -> foo.example.bar`: false is an invalid attribute value of `bar` - expecting (one of) [true]",
 				},
 			},
 		},
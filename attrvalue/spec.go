@@ -0,0 +1,278 @@
+package attrvalue
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// The kinds of rule that can be built from a Spec.
+const (
+	KindAllowed       = "allowed"        // The attribute value must be one of the expected values, see SimpleRule and SetRule.
+	KindUnknown       = "unknown"        // The attribute value must not be known, see UnknownValueRule.
+	KindRequired      = "required"       // The attribute must be specified, see MustExistRule.
+	KindPattern       = "pattern"        // The attribute value must match a regular expression, see PatternRule.
+	KindRange         = "range"          // The attribute value must be a number within bounds, see RangeRule.
+	KindForbidden     = "forbidden"      // The attribute value must not be one of the forbidden values, see ForbiddenRule.
+	KindNotAllowed    = "not_allowed"    // The resource type must not be used at all, see NotAllowedRule.
+	KindRequiredKeys  = "required_keys"  // The map or object attribute must have the keys, see KeysRule.
+	KindForbiddenKeys = "forbidden_keys" // The map or object attribute must not have the keys, see KeysRule.
+)
+
+var kinds = []string{KindAllowed, KindUnknown, KindRequired, KindPattern, KindRange, KindForbidden, KindNotAllowed, KindRequiredKeys, KindForbiddenKeys}
+
+// Spec describes an attribute value rule as data, so that rules can be declared without writing Go code.
+// The attribute name is required by all kinds but KindNotAllowed, which checks the resource type only.
+// It can be followed by a path to check a value inside a map or object attribute, e.g. `app_settings["WEBSITE_RUN_FROM_PACKAGE"]` or `identity.type`.
+type Spec struct {
+	Name            string
+	ResourceType    string
+	NestedBlockType *string // Nested blocks are separated by dots, e.g. "default_node_pool.upgrade_settings".
+	AttributeName   string
+	Kind            string
+	// BlockTypes are the kinds of block reported by KindNotAllowed, BlockTypeResource and/or BlockTypeData. Both when empty.
+	BlockTypes []string
+	// BlockType is the kind of block checked by all kinds but KindNotAllowed, BlockTypeResource, BlockTypeData or BlockTypeModule.
+	// Resources when empty. The resource type of a module call is its source, see On.
+	BlockType string
+	// ArmType limits all kinds but KindNotAllowed to the azapi resources of the ARM type, e.g. "Microsoft.Storage/storageAccounts", see ForArmType.
+	ArmType string
+	// ExpectedValues is a list of the allowed values for KindAllowed.
+	// A list of lists declares the allowed sets of values, the order of the values in the attribute does not matter.
+	ExpectedValues cty.Value
+	// ForbiddenValues is a list of the forbidden values for KindForbidden.
+	ForbiddenValues cty.Value
+	// Recommendation is the alternative suggested in the issues of KindForbidden and KindNotAllowed, e.g. "Standard".
+	Recommendation string
+	// Pattern is the regular expression the attribute value must match for KindPattern.
+	Pattern string
+	// Min and Max are the bounds of the attribute value for KindRange, at least one of them is required.
+	Min, Max *Bound
+	// Keys are the map or object keys for KindRequiredKeys and KindForbiddenKeys.
+	Keys []string
+	// Condition limits the rule to the resources that meet it, for all kinds but KindNotAllowed. Nil when the rule applies to all resources.
+	Condition *Condition
+	// MustExist also reports resources that do not specify the attribute, for all kinds but KindUnknown, KindRequired and KindNotAllowed.
+	MustExist bool
+	Link      string
+}
+
+// NewRuleFromSpec returns the rule described by the spec.
+// The type of the expected values decides the type of the rule, e.g. a list of strings makes a SimpleRule[string].
+func NewRuleFromSpec(s Spec) (tflint.Rule, error) {
+	if s.Kind == KindNotAllowed {
+		return newNotAllowedRuleFromSpec(s)
+	}
+	rule, err := newAttrValueRuleFromSpec(s)
+	if err != nil {
+		return nil, err
+	}
+	switch s.BlockType {
+	case "", BlockTypeResource:
+	case BlockTypeData, BlockTypeModule:
+		rule = On(rule.(blockTypeSetter), s.BlockType).(tflint.Rule)
+	default:
+		return nil, fmt.Errorf("%s: unknown block type %q, expecting one of %q", s.Name, s.BlockType, []string{BlockTypeResource, BlockTypeData, BlockTypeModule})
+	}
+	if s.ArmType != "" {
+		if s.ResourceType != AzapiResourceType {
+			return nil, fmt.Errorf("%s: an ARM type can only be set on %s, not %s", s.Name, AzapiResourceType, s.ResourceType)
+		}
+		rule = ForArmType(rule.(armTypeSetter), s.ArmType).(tflint.Rule)
+	}
+	if s.Condition == nil {
+		return rule, nil
+	}
+	return When(rule.(conditionSetter), *s.Condition).(tflint.Rule), nil
+}
+
+func newAttrValueRuleFromSpec(s Spec) (tflint.Rule, error) {
+	if s.AttributeName == "" {
+		return nil, fmt.Errorf("%s: an attribute is required for kind %q", s.Name, s.Kind)
+	}
+	if _, _, err := parseAttributePath(s.AttributeName); err != nil {
+		return nil, fmt.Errorf("%s: %w", s.Name, err)
+	}
+	switch s.Kind {
+	case KindUnknown:
+		if s.NestedBlockType != nil {
+			return NewUnknownValueNestedBlockRule(s.ResourceType, *s.NestedBlockType, s.AttributeName, s.Link, s.Name), nil
+		}
+		return NewUnknownValueRule(s.ResourceType, s.AttributeName, s.Link, s.Name), nil
+	case KindRequired:
+		if s.NestedBlockType != nil {
+			return NewMustExistNestedBlockRule(s.ResourceType, *s.NestedBlockType, s.AttributeName, s.Link, s.Name), nil
+		}
+		return NewMustExistRule(s.ResourceType, s.AttributeName, s.Link, s.Name), nil
+	case KindAllowed:
+		return newAllowedRuleFromSpec(s)
+	case KindPattern:
+		if s.Pattern == "" {
+			return nil, fmt.Errorf("%s: pattern must not be empty", s.Name)
+		}
+		if _, err := regexp.Compile(s.Pattern); err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern: %w", s.Name, err)
+		}
+		if s.NestedBlockType != nil {
+			return NewPatternNestedBlockRule(s.ResourceType, *s.NestedBlockType, s.AttributeName, s.Pattern, s.Link, s.MustExist, s.Name), nil
+		}
+		return NewPatternRule(s.ResourceType, s.AttributeName, s.Pattern, s.Link, s.MustExist, s.Name), nil
+	case KindForbidden:
+		return newForbiddenRuleFromSpec(s)
+	case KindRequiredKeys, KindForbiddenKeys:
+		return newKeysRuleFromSpec(s)
+	case KindRange:
+		if s.Min == nil && s.Max == nil {
+			return nil, fmt.Errorf("%s: a range needs a minimum, a maximum or both", s.Name)
+		}
+		if s.Min != nil && s.Max != nil && (s.Min.Value > s.Max.Value || (s.Min.Value == s.Max.Value && (s.Min.Exclusive || s.Max.Exclusive))) {
+			return nil, fmt.Errorf("%s: the range between %s and %s is empty", s.Name, s.Min, s.Max)
+		}
+		if s.NestedBlockType != nil {
+			return NewRangeNestedBlockRule(s.ResourceType, *s.NestedBlockType, s.AttributeName, s.Min, s.Max, s.Link, s.MustExist, s.Name), nil
+		}
+		return NewRangeRule(s.ResourceType, s.AttributeName, s.Min, s.Max, s.Link, s.MustExist, s.Name), nil
+	}
+	return nil, fmt.Errorf("%s: unknown kind %q, expecting one of %q", s.Name, s.Kind, kinds)
+}
+
+func newAllowedRuleFromSpec(s Spec) (tflint.Rule, error) {
+	v := s.ExpectedValues
+	if v == cty.NilVal || v.IsNull() || !v.IsKnown() || !v.CanIterateElements() || v.LengthInt() == 0 {
+		return nil, fmt.Errorf("%s: expected values must be a non-empty list", s.Name)
+	}
+	first := v.AsValueSlice()[0]
+	switch {
+	case first.Type().IsListType() || first.Type().IsTupleType() || first.Type().IsSetType():
+		if s.MustExist {
+			return nil, fmt.Errorf("%s: must exist is not supported with sets of expected values", s.Name)
+		}
+		// Sets of numbers make a SetRule[int], other sets keep their values as strings, e.g. the zones of an ARM body.
+		if elems := first.AsValueSlice(); len(elems) > 0 && elems[0].Type() == cty.Number {
+			if sets, err := expectedValuesAs[[][]int](v, cty.List(cty.List(cty.Number))); err == nil {
+				return newSetRuleFromSpec(s, sets), nil
+			}
+		}
+		sets, err := expectedValuesAs[[][]string](v, cty.List(cty.List(cty.String)))
+		if err != nil {
+			return nil, fmt.Errorf("%s: expected values must be lists of numbers or strings: %w", s.Name, err)
+		}
+		return newSetRuleFromSpec(s, sets), nil
+	case first.Type() == cty.Bool:
+		values, err := expectedValuesAs[[]bool](v, cty.List(cty.Bool))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Name, err)
+		}
+		return newSimpleRuleFromSpec(s, values), nil
+	case first.Type() == cty.Number:
+		if values, err := expectedValuesAs[[]int](v, cty.List(cty.Number)); err == nil {
+			return newSimpleRuleFromSpec(s, values), nil
+		}
+		values, err := expectedValuesAs[[]float64](v, cty.List(cty.Number))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Name, err)
+		}
+		return newSimpleRuleFromSpec(s, values), nil
+	default:
+		values, err := expectedValuesAs[[]string](v, cty.List(cty.String))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Name, err)
+		}
+		return newSimpleRuleFromSpec(s, values), nil
+	}
+}
+
+func newSetRuleFromSpec[T cmp.Ordered](s Spec, sets [][]T) *SetRule[T] {
+	if s.NestedBlockType != nil {
+		return NewSetNestedBlockRule(s.ResourceType, *s.NestedBlockType, s.AttributeName, sets, s.Link, s.Name)
+	}
+	return NewSetRule(s.ResourceType, s.AttributeName, sets, s.Link, s.Name)
+}
+
+func newSimpleRuleFromSpec[T any](s Spec, values []T) *SimpleRule[T] {
+	if s.NestedBlockType != nil {
+		return NewSimpleNestedBlockRule(s.ResourceType, *s.NestedBlockType, s.AttributeName, values, s.Link, s.MustExist, s.Name)
+	}
+	return NewSimpleRule(s.ResourceType, s.AttributeName, values, s.Link, s.MustExist, s.Name)
+}
+
+func newKeysRuleFromSpec(s Spec) (tflint.Rule, error) {
+	if len(s.Keys) == 0 {
+		return nil, fmt.Errorf("%s: keys must be a non-empty list for kind %q", s.Name, s.Kind)
+	}
+	switch {
+	case s.Kind == KindForbiddenKeys && s.NestedBlockType != nil:
+		return NewForbiddenKeysNestedBlockRule(s.ResourceType, *s.NestedBlockType, s.AttributeName, s.Keys, s.Link, s.MustExist, s.Name), nil
+	case s.Kind == KindForbiddenKeys:
+		return NewForbiddenKeysRule(s.ResourceType, s.AttributeName, s.Keys, s.Link, s.MustExist, s.Name), nil
+	case s.NestedBlockType != nil:
+		return NewRequiredKeysNestedBlockRule(s.ResourceType, *s.NestedBlockType, s.AttributeName, s.Keys, s.Link, s.MustExist, s.Name), nil
+	default:
+		return NewRequiredKeysRule(s.ResourceType, s.AttributeName, s.Keys, s.Link, s.MustExist, s.Name), nil
+	}
+}
+
+func newNotAllowedRuleFromSpec(s Spec) (tflint.Rule, error) {
+	if s.AttributeName != "" || s.NestedBlockType != nil || s.Condition != nil || s.BlockType != "" || s.ArmType != "" {
+		return nil, fmt.Errorf("%s: kind %q checks the resource type only, it cannot have an attribute, a nested block, a condition, a block type or an ARM type", s.Name, s.Kind)
+	}
+	for _, blockType := range s.BlockTypes {
+		if blockType != BlockTypeResource && blockType != BlockTypeData {
+			return nil, fmt.Errorf("%s: unknown block type %q, expecting %q or %q", s.Name, blockType, BlockTypeResource, BlockTypeData)
+		}
+	}
+	return NewNotAllowedRule(s.ResourceType, s.BlockTypes, s.Recommendation, s.Link, s.Name), nil
+}
+
+func newForbiddenRuleFromSpec(s Spec) (tflint.Rule, error) {
+	v := s.ForbiddenValues
+	if v == cty.NilVal || v.IsNull() || !v.IsKnown() || !v.CanIterateElements() || v.LengthInt() == 0 {
+		return nil, fmt.Errorf("%s: forbidden values must be a non-empty list", s.Name)
+	}
+	switch v.AsValueSlice()[0].Type() {
+	case cty.Bool:
+		values, err := expectedValuesAs[[]bool](v, cty.List(cty.Bool))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Name, err)
+		}
+		return newForbiddenRuleOf(s, values), nil
+	case cty.Number:
+		if values, err := expectedValuesAs[[]int](v, cty.List(cty.Number)); err == nil {
+			return newForbiddenRuleOf(s, values), nil
+		}
+		values, err := expectedValuesAs[[]float64](v, cty.List(cty.Number))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Name, err)
+		}
+		return newForbiddenRuleOf(s, values), nil
+	default:
+		values, err := expectedValuesAs[[]string](v, cty.List(cty.String))
+		if err != nil {
+			return nil, fmt.Errorf("%s: forbidden values must be numbers, bools or strings: %w", s.Name, err)
+		}
+		return newForbiddenRuleOf(s, values), nil
+	}
+}
+
+func newForbiddenRuleOf[T any](s Spec, values []T) *ForbiddenRule[T] {
+	if s.NestedBlockType != nil {
+		return NewForbiddenNestedBlockRule(s.ResourceType, *s.NestedBlockType, s.AttributeName, values, s.Recommendation, s.Link, s.MustExist, s.Name)
+	}
+	return NewForbiddenRule(s.ResourceType, s.AttributeName, values, s.Recommendation, s.Link, s.MustExist, s.Name)
+}
+
+// expectedValuesAs converts the expected values to the given cty type, then to the Go type T.
+func expectedValuesAs[T any](v cty.Value, ty cty.Type) (T, error) {
+	var values T
+	converted, err := convert.Convert(v, ty)
+	if err != nil {
+		return values, err
+	}
+	err = gocty.FromCtyValue(converted, &values)
+	return values, err
+}
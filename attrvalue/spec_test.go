@@ -0,0 +1,301 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestNewRuleFromSpec(t *testing.T) {
+	nestedBlock := "sku"
+	cases := []struct {
+		desc     string
+		spec     attrvalue.Spec
+		expected any
+	}{
+		{
+			desc:     "strings",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindAllowed, ExpectedValues: cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")})},
+			expected: &attrvalue.SimpleRule[string]{},
+		},
+		{
+			desc:     "strings in nested block",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindAllowed, NestedBlockType: &nestedBlock, ExpectedValues: cty.TupleVal([]cty.Value{cty.StringVal("a")})},
+			expected: &attrvalue.SimpleRule[string]{},
+		},
+		{
+			desc:     "bools",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindAllowed, ExpectedValues: cty.TupleVal([]cty.Value{cty.True})},
+			expected: &attrvalue.SimpleRule[bool]{},
+		},
+		{
+			desc:     "whole numbers",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindAllowed, ExpectedValues: cty.TupleVal([]cty.Value{cty.NumberIntVal(1)})},
+			expected: &attrvalue.SimpleRule[int]{},
+		},
+		{
+			desc:     "fractional numbers",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindAllowed, ExpectedValues: cty.TupleVal([]cty.Value{cty.NumberFloatVal(1.5)})},
+			expected: &attrvalue.SimpleRule[float64]{},
+		},
+		{
+			desc: "sets of numbers",
+			spec: attrvalue.Spec{Kind: attrvalue.KindAllowed, ExpectedValues: cty.TupleVal([]cty.Value{
+				cty.TupleVal([]cty.Value{cty.NumberIntVal(1), cty.NumberIntVal(2)}),
+			})},
+			expected: &attrvalue.SetRule[int]{},
+		},
+		{
+			desc: "sets of numeric strings",
+			spec: attrvalue.Spec{Kind: attrvalue.KindAllowed, ExpectedValues: cty.TupleVal([]cty.Value{
+				cty.TupleVal([]cty.Value{cty.StringVal("1"), cty.StringVal("2")}),
+			})},
+			expected: &attrvalue.SetRule[string]{},
+		},
+		{
+			desc: "sets of strings in nested block",
+			spec: attrvalue.Spec{Kind: attrvalue.KindAllowed, NestedBlockType: &nestedBlock, ExpectedValues: cty.TupleVal([]cty.Value{
+				cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+			})},
+			expected: &attrvalue.SetRule[string]{},
+		},
+		{
+			desc:     "unknown",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindUnknown},
+			expected: &attrvalue.UnknownValueRule{},
+		},
+		{
+			desc:     "required",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindRequired},
+			expected: &attrvalue.MustExistRule{},
+		},
+		{
+			desc:     "pattern",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindPattern, Pattern: "^TLS1_2$"},
+			expected: &attrvalue.PatternRule{},
+		},
+		{
+			desc:     "pattern in nested block",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindPattern, NestedBlockType: &nestedBlock, Pattern: "^Standard_"},
+			expected: &attrvalue.PatternRule{},
+		},
+		{
+			desc:     "range",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindRange, Min: attrvalue.Inclusive(7), Max: attrvalue.Exclusive(30)},
+			expected: &attrvalue.RangeRule{},
+		},
+		{
+			desc:     "range with minimum only in nested block",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindRange, NestedBlockType: &nestedBlock, Min: attrvalue.Inclusive(2)},
+			expected: &attrvalue.RangeRule{},
+		},
+		{
+			desc:     "forbidden strings",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindForbidden, ForbiddenValues: cty.TupleVal([]cty.Value{cty.StringVal("Basic")}), Recommendation: "Standard"},
+			expected: &attrvalue.ForbiddenRule[string]{},
+		},
+		{
+			desc:     "forbidden bools in nested block",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindForbidden, NestedBlockType: &nestedBlock, ForbiddenValues: cty.TupleVal([]cty.Value{cty.True})},
+			expected: &attrvalue.ForbiddenRule[bool]{},
+		},
+		{
+			desc:     "required keys",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindRequiredKeys, Keys: []string{"environment"}},
+			expected: &attrvalue.KeysRule{},
+		},
+		{
+			desc:     "forbidden keys in nested block",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindForbiddenKeys, NestedBlockType: &nestedBlock, Keys: []string{"WEBSITE_RUN_FROM_PACKAGE"}},
+			expected: &attrvalue.KeysRule{},
+		},
+		{
+			desc:     "forbidden whole numbers",
+			spec:     attrvalue.Spec{Kind: attrvalue.KindForbidden, ForbiddenValues: cty.TupleVal([]cty.Value{cty.NumberIntVal(1)})},
+			expected: &attrvalue.ForbiddenRule[int]{},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.spec.Name = "test"
+			tc.spec.ResourceType = "foo"
+			tc.spec.AttributeName = "bar"
+			rule, err := attrvalue.NewRuleFromSpec(tc.spec)
+			require.NoError(t, err)
+			assert.IsType(t, tc.expected, rule)
+			assert.Equal(t, "test", rule.Name())
+			assert.Equal(t, "foo", rule.(attrvalue.AttrValueRule).GetResourceType())
+		})
+	}
+}
+
+func TestNewNotAllowedRuleFromSpec(t *testing.T) {
+	rule, err := attrvalue.NewRuleFromSpec(attrvalue.Spec{
+		Name:           "test",
+		ResourceType:   "foo",
+		Kind:           attrvalue.KindNotAllowed,
+		BlockTypes:     []string{attrvalue.BlockTypeResource},
+		Recommendation: "bar",
+	})
+	require.NoError(t, err)
+	require.IsType(t, &attrvalue.NotAllowedRule{}, rule)
+	notAllowed := rule.(*attrvalue.NotAllowedRule)
+	assert.Equal(t, "test", notAllowed.Name())
+	assert.Equal(t, "foo", notAllowed.GetResourceType())
+	assert.Equal(t, []string{attrvalue.BlockTypeResource}, notAllowed.GetBlockTypes())
+	assert.Equal(t, "bar", notAllowed.GetRecommendation())
+}
+
+func TestNewRuleFromSpecWithCondition(t *testing.T) {
+	condition := &attrvalue.Condition{AttributeName: "sku", Values: []cty.Value{cty.StringVal("Standard")}}
+	rule, err := attrvalue.NewRuleFromSpec(attrvalue.Spec{
+		Name:           "test",
+		ResourceType:   "foo",
+		AttributeName:  "zones",
+		Kind:           attrvalue.KindAllowed,
+		ExpectedValues: cty.TupleVal([]cty.Value{cty.TupleVal([]cty.Value{cty.NumberIntVal(1), cty.NumberIntVal(2), cty.NumberIntVal(3)})}),
+		Condition:      condition,
+	})
+	require.NoError(t, err)
+	require.Implements(t, (*attrvalue.Conditional)(nil), rule)
+	assert.Equal(t, condition, rule.(attrvalue.Conditional).GetCondition())
+}
+
+func TestNewRuleFromSpecWithBlockType(t *testing.T) {
+	rule, err := attrvalue.NewRuleFromSpec(attrvalue.Spec{
+		Name:          "test",
+		ResourceType:  "Azure/avm-res-network-loadbalancer/azurerm",
+		AttributeName: "sku",
+		Kind:          attrvalue.KindPattern,
+		Pattern:       "^Standard$",
+		BlockType:     attrvalue.BlockTypeModule,
+	})
+	require.NoError(t, err)
+	require.Implements(t, (*attrvalue.AttrValueRule)(nil), rule)
+	assert.Equal(t, attrvalue.BlockTypeModule, rule.(attrvalue.AttrValueRule).GetBlockType())
+}
+
+func TestNewRuleFromSpecWithArmType(t *testing.T) {
+	rule, err := attrvalue.NewRuleFromSpec(attrvalue.Spec{
+		Name:           "test",
+		ResourceType:   attrvalue.AzapiResourceType,
+		ArmType:        "Microsoft.Network/loadBalancers",
+		AttributeName:  "body.sku.name",
+		Kind:           attrvalue.KindAllowed,
+		ExpectedValues: cty.TupleVal([]cty.Value{cty.StringVal("Standard")}),
+	})
+	require.NoError(t, err)
+	require.Implements(t, (*attrvalue.AttrValueRule)(nil), rule)
+	assert.Equal(t, "Microsoft.Network/loadBalancers", rule.(attrvalue.AttrValueRule).GetArmType())
+}
+
+func TestNewRuleFromSpecErrors(t *testing.T) {
+	cases := []struct {
+		desc string
+		spec attrvalue.Spec
+	}{
+		{
+			desc: "unknown kind",
+			spec: attrvalue.Spec{Kind: "denied", AttributeName: "bar"},
+		},
+		{
+			desc: "no expected values",
+			spec: attrvalue.Spec{Kind: attrvalue.KindAllowed, AttributeName: "bar"},
+		},
+		{
+			desc: "empty expected values",
+			spec: attrvalue.Spec{Kind: attrvalue.KindAllowed, AttributeName: "bar", ExpectedValues: cty.EmptyTupleVal},
+		},
+		{
+			desc: "sets of objects",
+			spec: attrvalue.Spec{Kind: attrvalue.KindAllowed, AttributeName: "bar", ExpectedValues: cty.TupleVal([]cty.Value{
+				cty.TupleVal([]cty.Value{cty.EmptyObjectVal}),
+			})},
+		},
+		{
+			desc: "no pattern",
+			spec: attrvalue.Spec{Kind: attrvalue.KindPattern, AttributeName: "bar"},
+		},
+		{
+			desc: "invalid pattern",
+			spec: attrvalue.Spec{Kind: attrvalue.KindPattern, AttributeName: "bar", Pattern: "TLS1_("},
+		},
+		{
+			desc: "no forbidden values",
+			spec: attrvalue.Spec{Kind: attrvalue.KindForbidden, AttributeName: "bar"},
+		},
+		{
+			desc: "forbidden lists",
+			spec: attrvalue.Spec{Kind: attrvalue.KindForbidden, AttributeName: "bar", ForbiddenValues: cty.TupleVal([]cty.Value{
+				cty.TupleVal([]cty.Value{cty.StringVal("1")}),
+			})},
+		},
+		{
+			desc: "no attribute",
+			spec: attrvalue.Spec{Kind: attrvalue.KindRequired, ResourceType: "foo"},
+		},
+		{
+			desc: "not allowed with an attribute",
+			spec: attrvalue.Spec{Kind: attrvalue.KindNotAllowed, ResourceType: "foo", AttributeName: "bar"},
+		},
+		{
+			desc: "not allowed with a condition",
+			spec: attrvalue.Spec{Kind: attrvalue.KindNotAllowed, ResourceType: "foo", Condition: &attrvalue.Condition{AttributeName: "sku"}},
+		},
+		{
+			desc: "not allowed with an unknown block type",
+			spec: attrvalue.Spec{Kind: attrvalue.KindNotAllowed, ResourceType: "foo", BlockTypes: []string{"module"}},
+		},
+		{
+			desc: "not allowed with a block type",
+			spec: attrvalue.Spec{Kind: attrvalue.KindNotAllowed, ResourceType: "foo", BlockType: attrvalue.BlockTypeData},
+		},
+		{
+			desc: "not allowed with an ARM type",
+			spec: attrvalue.Spec{Kind: attrvalue.KindNotAllowed, ResourceType: attrvalue.AzapiResourceType, ArmType: "Microsoft.Network/loadBalancers"},
+		},
+		{
+			desc: "unknown block type",
+			spec: attrvalue.Spec{Kind: attrvalue.KindRequired, ResourceType: "foo", AttributeName: "bar", BlockType: "provider"},
+		},
+		{
+			desc: "no bounds",
+			spec: attrvalue.Spec{Kind: attrvalue.KindRange, AttributeName: "bar"},
+		},
+		{
+			desc: "empty range",
+			spec: attrvalue.Spec{Kind: attrvalue.KindRange, AttributeName: "bar", Min: attrvalue.Inclusive(3), Max: attrvalue.Exclusive(3)},
+		},
+		{
+			desc: "invalid attribute path",
+			spec: attrvalue.Spec{Kind: attrvalue.KindRequired, ResourceType: "foo", AttributeName: "app_settings["},
+		},
+		{
+			desc: "unterminated index in the attribute path",
+			spec: attrvalue.Spec{Kind: attrvalue.KindAllowed, ResourceType: "foo", AttributeName: `app_settings["x"`, ExpectedValues: cty.TupleVal([]cty.Value{cty.StringVal("1")})},
+		},
+		{
+			desc: "ARM type on an azurerm resource",
+			spec: attrvalue.Spec{Kind: attrvalue.KindRequired, ResourceType: "azurerm_lb", AttributeName: "sku", ArmType: "Microsoft.Network/loadBalancers"},
+		},
+		{
+			desc: "no keys",
+			spec: attrvalue.Spec{Kind: attrvalue.KindRequiredKeys, AttributeName: "tags"},
+		},
+		{
+			desc: "minimum above maximum",
+			spec: attrvalue.Spec{Kind: attrvalue.KindRange, AttributeName: "bar", Min: attrvalue.Inclusive(7), Max: attrvalue.Inclusive(1)},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := attrvalue.NewRuleFromSpec(tc.spec)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -55,7 +55,7 @@ func (r *UnknownValueRule) Name() string {
 }
 
 func (r *UnknownValueRule) Check(runner tflint.Runner) error {
-	return r.checkAttributes(runner, cty.DynamicPseudoType, func(attr *hclext.Attribute, val cty.Value) error {
+	return r.checkAttributes(runner, cty.DynamicPseudoType, func(runner tflint.Runner, attr *hclext.Attribute, val cty.Value) error {
 		if val.IsKnown() {
 			return runner.EmitIssue(
 				r,
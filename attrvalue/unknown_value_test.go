@@ -44,7 +44,7 @@ func TestUnknownValueRule(t *testing.T) {
 			expected: helper.Issues{
 				{
 					Rule:    attrvalue.NewUnknownValueRule("foo", "bar", "", ""),
-					Message: "invalid attribute value of `bar` - expecting unknown",
+					Message: "`local.bar -> foo.example.bar`: invalid attribute value of `bar` - expecting unknown",
 				},
 			},
 		},
@@ -62,7 +62,7 @@ func TestUnknownValueRule(t *testing.T) {
 			expected: helper.Issues{
 				{
 					Rule:    attrvalue.NewUnknownValueRule("foo", "bar", "", ""),
-					Message: "invalid attribute value of `bar` - expecting unknown",
+					Message: "`var.test (default) -> foo.example.bar`: invalid attribute value of `bar` - expecting unknown",
 				},
 			},
 		},
@@ -92,7 +92,7 @@ func TestUnknownValueRule(t *testing.T) {
 			expected: helper.Issues{
 				{
 					Rule:    attrvalue.NewUnknownValueRule("foo", "bar", "", ""),
-					Message: "invalid attribute value of `bar` - expecting unknown",
+					Message: "`var.test (default) -> foo.example.bar`: invalid attribute value of `bar` - expecting unknown",
 				},
 			},
 		},
@@ -0,0 +1,224 @@
+package attrvalue
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// RuleConfig is the configuration of an attribute value rule, decoded from its `rule` block.
+type RuleConfig struct {
+	// Strict reports the unknown values that the validation blocks of their variable do not prove compliant.
+	Strict bool `hclext:"strict,optional"`
+}
+
+// ruleConfig returns the configuration of the rule declared in its `rule` block, if any.
+func ruleConfig(runner tflint.Runner, rule tflint.Rule) (*RuleConfig, error) {
+	config := &RuleConfig{}
+	if err := runner.DecodeRuleConfig(rule.Name(), config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// checkValues is checkAttributes for the rules that check known values, which skip unknown values.
+// In strict mode, an unknown value of a resource of the root module is checked with checkUnknown.
+func (b baseValue) checkValues(r tflint.Runner, rule tflint.Rule, ct cty.Type, c func(tflint.Runner, *hclext.Attribute, cty.Value) error) error {
+	config, err := ruleConfig(r, rule)
+	if err != nil {
+		return err
+	}
+	return b.eachAttribute(r, ct, func(resource *resourceInstance, attr *hclext.Attribute, val cty.Value) error {
+		if resource.module.caller == nil {
+			if branches := resource.branches(attr.Expr); len(branches) > 1 {
+				return b.checkBranches(r, rule, config, resource, attr, branches, ct, c)
+			}
+		}
+		if config.Strict && !val.IsKnown() && resource.module.caller == nil {
+			return b.checkUnknown(r, rule, resource, attr, ct, c)
+		}
+		return c(resource.runner(r, attr.Expr, b.attributePath()), attr, val)
+	})
+}
+
+// checkBranches checks the value of each reachable branch of the attribute of a resource of the root module, see branches,
+// and reports the issues found in a branch at its expression, naming the conditions leading to it.
+// A branch that cannot be evaluated, e.g. the failing first argument of try(), is checked as an unknown value.
+func (b baseValue) checkBranches(r tflint.Runner, rule tflint.Rule, config *RuleConfig, resource *resourceInstance, attr *hclext.Attribute, branches []*branch, ct cty.Type, c func(tflint.Runner, *hclext.Attribute, cty.Value) error) error {
+	for _, br := range branches {
+		val, err := b.evaluate(resource.module.ctx, resource.bind(br.expr), ct)
+		if err != nil {
+			val = cty.UnknownVal(ct)
+		}
+		if config.Strict && !val.IsKnown() {
+			if err := b.checkUnknown(r, rule, resource, &hclext.Attribute{Name: attr.Name, Expr: br.expr, Range: br.expr.Range()}, ct, c); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c(br.runner(r, resource, b.attributePath()), attr, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUnknown checks an unknown attribute value that refers to a variable against the values allowed by the validation blocks of the variable,
+// see validationOf, and reports the values breaking the rule at the validation condition.
+// A value that is not constrained by a validation cannot be proven compliant and is reported.
+func (b baseValue) checkUnknown(r tflint.Runner, rule tflint.Rule, resource *resourceInstance, attr *hclext.Attribute, ct cty.Type, c func(tflint.Runner, *hclext.Attribute, cty.Value) error) error {
+	v := validationOf(resource.module, attr.Expr)
+	if v == nil {
+		return resource.runner(r, attr.Expr, b.attributePath()).EmitIssue(
+			rule,
+			fmt.Sprintf("The value of `%s` is not known and no variable validation proves it compliant", b.attributeName),
+			attr.Range,
+		)
+	}
+	runner := &originRunner{
+		Runner: r,
+		origin: &origin{
+			rng:   v.rng,
+			chain: []string{v.reference + " (validation)", fmt.Sprintf("%s.%s", resource.address(), b.attributePath())},
+		},
+		issues: resource.issues,
+	}
+	for _, allowed := range v.values {
+		val, err := convert.Convert(valueAtPath(allowed, b.valuePath), ct)
+		if err != nil {
+			continue // The type constraint of the variable rejects the value anyway.
+		}
+		if err := c(runner, attr, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validation is the set of values a variable reference is constrained to by the validation blocks of the variable.
+type validation struct {
+	rng       hcl.Range // The range of the validation condition constraining the values
+	reference string    // e.g. `var.sku`
+	values    []cty.Value
+}
+
+// validationOf returns the values allowed by the validation blocks of the variable of the root module the expression refers to,
+// or nil if the expression is not a reference to a variable or no validation constrains it to a set of values.
+// Conditions such as `contains(["ZRS", "GRS"], var.sku)`, `var.sku == "ZRS"`, and their combinations with `||` and `&&` constrain the values.
+func validationOf(module *moduleInstance, expr hcl.Expression) *validation {
+	traversal, ok := referenceOf(expr)
+	if !ok || module.caller != nil || traversal.RootName() != "var" || len(traversal) < 2 {
+		return nil
+	}
+	name, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return nil
+	}
+	variable := variableBlock(module, name.Name)
+	if variable == nil {
+		return nil
+	}
+
+	var v *validation
+	reference := traversalString(traversal)
+	for _, block := range variable.Body.Blocks.OfType("validation") {
+		condition, ok := block.Body.Attributes["condition"]
+		if !ok {
+			continue
+		}
+		values, ok := allowedValues(condition.Expr, reference)
+		if !ok {
+			continue
+		}
+		if v == nil {
+			v = &validation{rng: condition.Range, reference: reference, values: values}
+			continue
+		}
+		v.values = intersect(v.values, values)
+	}
+	return v
+}
+
+// allowedValues returns the values of the reference that meet the condition, if the condition constrains it to a set of values.
+func allowedValues(condition hcl.Expression, reference string) ([]cty.Value, bool) {
+	switch e := condition.(type) {
+	case *hclsyntax.ParenthesesExpr:
+		return allowedValues(e.Expression, reference)
+	case *hclsyntax.FunctionCallExpr:
+		if e.Name != "contains" || len(e.Args) != 2 || !isReference(e.Args[1], reference) {
+			return nil, false
+		}
+		list, diags := e.Args[0].Value(nil)
+		if diags.HasErrors() || !list.IsWhollyKnown() || list.IsNull() || !list.CanIterateElements() {
+			return nil, false
+		}
+		var values []cty.Value
+		for it := list.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			values = append(values, v)
+		}
+		return values, true
+	case *hclsyntax.BinaryOpExpr:
+		switch e.Op {
+		case hclsyntax.OpEqual:
+			if isReference(e.LHS, reference) {
+				return literalValue(e.RHS)
+			}
+			if isReference(e.RHS, reference) {
+				return literalValue(e.LHS)
+			}
+		case hclsyntax.OpLogicalOr:
+			lhs, lok := allowedValues(e.LHS, reference)
+			rhs, rok := allowedValues(e.RHS, reference)
+			if lok && rok {
+				return append(lhs, rhs...), true
+			}
+		case hclsyntax.OpLogicalAnd:
+			lhs, lok := allowedValues(e.LHS, reference)
+			rhs, rok := allowedValues(e.RHS, reference)
+			switch {
+			case lok && rok:
+				return intersect(lhs, rhs), true
+			case lok:
+				return lhs, true
+			case rok:
+				return rhs, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// isReference returns whether the expression is the given reference, e.g. `var.sku`.
+func isReference(expr hcl.Expression, reference string) bool {
+	traversal, ok := referenceOf(expr)
+	return ok && traversalString(traversal) == reference
+}
+
+// literalValue returns the value of an expression that does not refer to anything, e.g. `"ZRS"` or `null`.
+func literalValue(expr hcl.Expression) ([]cty.Value, bool) {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() {
+		return nil, false
+	}
+	return []cty.Value{val}, true
+}
+
+// intersect returns the values of a that are in b.
+func intersect(a, b []cty.Value) []cty.Value {
+	var values []cty.Value
+	for _, va := range a {
+		for _, vb := range b {
+			if va.RawEquals(vb) {
+				values = append(values, va)
+				break
+			}
+		}
+	}
+	return values
+}
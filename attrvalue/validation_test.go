@@ -0,0 +1,199 @@
+package attrvalue_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const strictConfig = `
+rule "house_sku" {
+  enabled = true
+  strict  = true
+}
+rule "house_retention_days" {
+  enabled = true
+  strict  = true
+}`
+
+func TestValidationValueRule(t *testing.T) {
+	sku := attrvalue.NewSimpleRule("foo", "sku", []string{"Standard", "Premium"}, "", false, "house_sku")
+	retentionDays := attrvalue.NewRangeRule("foo", "retention_days", attrvalue.Inclusive(7), nil, "", false, "house_retention_days")
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		config   string
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name:   "validation allowing compliant values only",
+			rule:   sku,
+			config: strictConfig,
+			content: `
+	variable "sku" {
+		type = string
+		validation {
+			condition     = contains(["Standard", "Premium"], var.sku)
+			error_message = "Invalid SKU."
+		}
+	}
+	resource "foo" "this" {
+		sku = var.sku
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name:   "validation allowing a non-compliant value",
+			rule:   sku,
+			config: strictConfig,
+			content: `
+	variable "sku" {
+		type = string
+		validation {
+			condition     = contains(["Basic", "Standard"], var.sku)
+			error_message = "Invalid SKU."
+		}
+	}
+	resource "foo" "this" {
+		sku = var.sku
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    sku,
+					Message: "`var.sku (validation) -> foo.this.sku`: Basic is an invalid attribute value of `sku` - expecting (one of) [Standard Premium]",
+				},
+			},
+		},
+		{
+			name:   "comparisons",
+			rule:   sku,
+			config: strictConfig,
+			content: `
+	variable "sku" {
+		type = string
+		validation {
+			condition     = var.sku == null || (var.sku == "Standard" || "Premium" == var.sku)
+			error_message = "Invalid SKU."
+		}
+	}
+	resource "foo" "this" {
+		sku = var.sku
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name:   "validations narrowing each other",
+			rule:   sku,
+			config: strictConfig,
+			content: `
+	variable "sku" {
+		type = string
+		validation {
+			condition     = contains(["Basic", "Standard"], var.sku)
+			error_message = "Invalid SKU."
+		}
+		validation {
+			condition     = var.sku != "Basic" && contains(["Standard", "Premium"], var.sku)
+			error_message = "Basic SKU is retired."
+		}
+	}
+	resource "foo" "this" {
+		sku = var.sku
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name:   "validation not constraining the value to a set",
+			rule:   sku,
+			config: strictConfig,
+			content: `
+	variable "sku" {
+		type = string
+		validation {
+			condition     = can(regex("^S", var.sku))
+			error_message = "Invalid SKU."
+		}
+	}
+	resource "foo" "this" {
+		sku = var.sku
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    sku,
+					Message: "The value of `sku` is not known and no variable validation proves it compliant",
+				},
+			},
+		},
+		{
+			name:   "no validation",
+			rule:   sku,
+			config: strictConfig,
+			content: `
+	variable "sku" {
+		type = string
+	}
+	resource "foo" "this" {
+		sku = var.sku
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    sku,
+					Message: "The value of `sku` is not known and no variable validation proves it compliant",
+				},
+			},
+		},
+		{
+			name: "no validation without strict mode",
+			rule: sku,
+			content: `
+	variable "sku" {
+		type = string
+	}
+	resource "foo" "this" {
+		sku = var.sku
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name:   "range of numbers",
+			rule:   retentionDays,
+			config: strictConfig,
+			content: `
+	variable "retention_days" {
+		type = number
+		validation {
+			condition     = contains([7, 14, 3], var.retention_days)
+			error_message = "Invalid retention."
+		}
+	}
+	resource "foo" "this" {
+		retention_days = var.retention_days
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    retentionDays,
+					Message: "`var.retention_days (validation) -> foo.this.retention_days`: 3 is an invalid attribute value of `retention_days` - expecting a value >= 7",
+				},
+			},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content, ".tflint.hcl": tc.config})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
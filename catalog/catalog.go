@@ -0,0 +1,233 @@
+// Package catalog describes the rules shipped by the plugin in a machine-readable form,
+// so that tflint results can be mapped to AVM spec items and WAF recommendations without reading the Go source.
+package catalog
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/Azure/tflint-ruleset-avm/waf"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// The kinds of attribute check.
+const (
+	CheckKindAllowed       = attrvalue.KindAllowed       // The attribute value must be one of the expected values.
+	CheckKindUnknown       = attrvalue.KindUnknown       // The attribute value must not be known, e.g. it comes from a variable without a default.
+	CheckKindRequired      = attrvalue.KindRequired      // The attribute must be specified, whatever its value.
+	CheckKindPattern       = attrvalue.KindPattern       // The attribute value must match a regular expression.
+	CheckKindRange         = attrvalue.KindRange         // The attribute value must be a number within bounds.
+	CheckKindForbidden     = attrvalue.KindForbidden     // The attribute value must not be one of the forbidden values.
+	CheckKindRequiredKeys  = attrvalue.KindRequiredKeys  // The map or object attribute must have the keys.
+	CheckKindForbiddenKeys = attrvalue.KindForbiddenKeys // The map or object attribute must not have the keys.
+)
+
+// The kinds of block an attribute check can target besides resources.
+const (
+	BlockTypeData   = attrvalue.BlockTypeData   // The resource type is the type of the data source.
+	BlockTypeModule = attrvalue.BlockTypeModule // The resource type is the source of the called module.
+)
+
+// Entry describes a rule.
+type Entry struct {
+	Name          string           `json:"name"`
+	Category      string           `json:"category"`
+	SpecID        string           `json:"spec_id,omitempty"`
+	AprlID        string           `json:"aprl_id,omitempty"`
+	Level         string           `json:"level,omitempty"`
+	ModuleClasses []string         `json:"module_classes,omitempty"`
+	Since         string           `json:"since,omitempty"`
+	Link          string           `json:"link"`
+	Severity      string           `json:"severity"`
+	Enabled       bool             `json:"enabled"`
+	Attribute     *AttributeCheck  `json:"attribute,omitempty"`
+	NotAllowed    *NotAllowedCheck `json:"not_allowed,omitempty"`
+}
+
+// NotAllowedCheck describes the resource type that must not be used, reported by a not allowed rule.
+type NotAllowedCheck struct {
+	ResourceType   string   `json:"resource_type"`
+	BlockTypes     []string `json:"block_types"`
+	Recommendation string   `json:"recommendation,omitempty"`
+}
+
+// AttributeCheck describes the attribute checked by an attribute value rule.
+type AttributeCheck struct {
+	Kind            string     `json:"kind"`
+	BlockType       string     `json:"block_type,omitempty"` // "data" or "module", empty for resources.
+	ResourceType    string     `json:"resource_type"`        // The source of the called module for module calls.
+	ArmType         string     `json:"arm_type,omitempty"`   // The ARM type of the checked azapi resources, e.g. "Microsoft.Network/loadBalancers".
+	NestedBlock     string     `json:"nested_block,omitempty"`
+	Attribute       string     `json:"attribute"` // The attribute name, followed by the path to the checked value for map and object attributes, e.g. `identity.type`.
+	ExpectedValues  []any      `json:"expected_values,omitempty"`
+	ForbiddenValues []any      `json:"forbidden_values,omitempty"`
+	Recommendation  string     `json:"recommendation,omitempty"`
+	Pattern         string     `json:"pattern,omitempty"`
+	Keys            []string   `json:"keys,omitempty"`
+	Range           *Range     `json:"range,omitempty"`
+	Condition       *Condition `json:"condition,omitempty"`
+}
+
+// Condition describes the resources an attribute check is limited to, by the value of another attribute.
+type Condition struct {
+	Attribute   string `json:"attribute"`
+	Values      []any  `json:"values,omitempty"`
+	Pattern     string `json:"pattern,omitempty"`
+	Description string `json:"description"` // e.g. "`sku` is one of "Standard""
+}
+
+// Range describes the bounds of a range check, nil bounds are unbounded.
+type Range struct {
+	Min          *float64 `json:"min,omitempty"`
+	MinExclusive bool     `json:"min_exclusive,omitempty"`
+	Max          *float64 `json:"max,omitempty"`
+	MaxExclusive bool     `json:"max_exclusive,omitempty"`
+	Description  string   `json:"description"` // e.g. `>= 7 and < 30`
+}
+
+// Build returns the catalog entries of the given rules, in the same order.
+func Build(rs []tflint.Rule) []Entry {
+	entries := make([]Entry, 0, len(rs))
+	for _, rule := range rs {
+		entries = append(entries, NewEntry(rule))
+	}
+	return entries
+}
+
+// NewEntry returns the catalog entry of a rule.
+func NewEntry(rule tflint.Rule) Entry {
+	e := Entry{
+		Name:     rule.Name(),
+		Link:     rule.Link(),
+		Severity: strings.ToLower(rule.Severity().String()),
+		Enabled:  rule.Enabled(),
+	}
+	if md, ok := rules.MetadataOf(rule.Name()); ok {
+		e.Category = md.Category
+		e.SpecID = md.SpecID
+		e.Level = string(md.Level)
+		e.Since = md.Since
+		for _, c := range md.ModuleClasses {
+			e.ModuleClasses = append(e.ModuleClasses, string(c))
+		}
+	}
+	e.AprlID = aprlID(rule)
+	switch r := common.UnwrapRule(rule).(type) {
+	case attrvalue.AttrValueRule:
+		e.Attribute = newAttributeCheck(r)
+	case *attrvalue.NotAllowedRule:
+		e.NotAllowed = &NotAllowedCheck{
+			ResourceType:   r.GetResourceType(),
+			BlockTypes:     r.GetBlockTypes(),
+			Recommendation: r.GetRecommendation(),
+		}
+	}
+	return e
+}
+
+// aprlID returns the ID of the APRL recommendation enforced by the rule, looking through the rules it wraps.
+func aprlID(rule tflint.Rule) string {
+	for {
+		if r, ok := rule.(waf.Recommendation); ok {
+			return r.AprlID()
+		}
+		w, ok := rule.(common.RuleWrapper)
+		if !ok {
+			return ""
+		}
+		rule = w.Unwrap()
+	}
+}
+
+func newAttributeCheck(av attrvalue.AttrValueRule) *AttributeCheck {
+	c := &AttributeCheck{
+		Kind:         CheckKindUnknown,
+		ResourceType: av.GetResourceType(),
+		Attribute:    av.GetAttributeName(),
+	}
+	if bt := av.GetBlockType(); bt != attrvalue.BlockTypeResource {
+		c.BlockType = bt
+	}
+	c.ArmType = av.GetArmType()
+	if nb := av.GetNestedBlockType(); nb != nil {
+		c.NestedBlock = *nb
+	}
+	if _, ok := av.(*attrvalue.MustExistRule); ok {
+		c.Kind = CheckKindRequired
+	}
+	if fv, ok := av.(attrvalue.ForbiddenValuesRule); ok {
+		c.Kind = CheckKindForbidden
+		c.ForbiddenValues = fv.GetForbiddenValues()
+		c.Recommendation = fv.GetRecommendation()
+	}
+	if kr, ok := av.(*attrvalue.KeysRule); ok {
+		c.Kind = CheckKindRequiredKeys
+		if kr.KeysForbidden() {
+			c.Kind = CheckKindForbiddenKeys
+		}
+		c.Keys = kr.GetKeys()
+	}
+	if p, ok := av.(*attrvalue.PatternRule); ok {
+		c.Kind = CheckKindPattern
+		c.Pattern = p.GetPattern()
+	}
+	if cr, ok := av.(attrvalue.Conditional); ok && cr.GetCondition() != nil {
+		c.Condition = newCondition(*cr.GetCondition())
+	}
+	if rr, ok := av.(*attrvalue.RangeRule); ok {
+		c.Kind = CheckKindRange
+		c.Range = &Range{Description: rr.Describe()}
+		min, max := rr.GetBounds()
+		if min != nil {
+			c.Range.Min, c.Range.MinExclusive = &min.Value, min.Exclusive
+		}
+		if max != nil {
+			c.Range.Max, c.Range.MaxExclusive = &max.Value, max.Exclusive
+		}
+	}
+	if ev, ok := av.(attrvalue.ExpectedValuesRule); ok {
+		c.Kind = CheckKindAllowed
+		c.ExpectedValues = ev.GetExpectedValues()
+	}
+	return c
+}
+
+func newCondition(cond attrvalue.Condition) *Condition {
+	c := &Condition{
+		Attribute:   cond.AttributeName,
+		Description: cond.String(),
+	}
+	if cond.Pattern != nil {
+		c.Pattern = cond.Pattern.String()
+	}
+	for _, v := range cond.Values {
+		c.Values = append(c.Values, goValue(v))
+	}
+	return c
+}
+
+// goValue returns the Go value of a primitive cty value, as it is encoded in JSON.
+func goValue(v cty.Value) any {
+	switch v.Type() {
+	case cty.String:
+		return v.AsString()
+	case cty.Bool:
+		return v.True()
+	case cty.Number:
+		f, _ := v.AsBigFloat().Float64()
+		return f
+	}
+	return v.GoString()
+}
+
+// Write writes the catalog of the given rules as indented JSON.
+func Write(w io.Writer, rs []tflint.Rule) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Build(rs))
+}
@@ -0,0 +1,215 @@
+package catalog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/Azure/tflint-ruleset-avm/catalog"
+	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func entriesByName(t *testing.T) map[string]catalog.Entry {
+	var buf bytes.Buffer
+	require.NoError(t, catalog.Write(&buf, rules.Rules))
+	var entries []catalog.Entry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entries))
+	require.Len(t, entries, len(rules.Rules))
+
+	byName := make(map[string]catalog.Entry, len(entries))
+	for _, e := range entries {
+		byName[e.Name] = e
+	}
+	return byName
+}
+
+func TestCatalogEntries(t *testing.T) {
+	entries := entriesByName(t)
+
+	cases := []struct {
+		name     string
+		expected catalog.Entry
+	}{
+		{
+			name: "required_module_source_tffr1",
+			expected: catalog.Entry{
+				Name:          "required_module_source_tffr1",
+				Category:      rules.CategoryRules,
+				SpecID:        "TFFR1",
+				Level:         "MUST",
+				ModuleClasses: []string{"resource", "pattern", "utility"},
+				Since:         "0.1.0",
+				Link:          "https://azure.github.io/Azure-Verified-Modules/specs/terraform/#id-tffr1---category-composition---cross-referencing-modules",
+				Severity:      "error",
+				Enabled:       true,
+			},
+		},
+		{
+			name: "required_output_rmfr7",
+			expected: catalog.Entry{
+				Name:          "required_output_rmfr7",
+				Category:      rules.CategoryOutputs,
+				SpecID:        "RMFR7",
+				Level:         "MUST",
+				ModuleClasses: []string{"resource"},
+				Since:         "0.1.0",
+				Link:          "https://azure.github.io/Azure-Verified-Modules/specs/shared/#id-rmfr7---category-outputs---minimum-required-outputs",
+				Severity:      "error",
+				Enabled:       true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, entries[tc.name])
+		})
+	}
+}
+
+func TestCatalogAttributeChecks(t *testing.T) {
+	entries := entriesByName(t)
+
+	sku := entries["waf_agw_4_sku_name"]
+	require.NotNil(t, sku.Attribute)
+	assert.Equal(t, rules.CategoryWaf, sku.Category)
+	assert.Equal(t, "AGW-4", sku.AprlID)
+	assert.Equal(t, "warning", sku.Severity)
+	assert.Equal(t, &catalog.AttributeCheck{
+		Kind:           catalog.CheckKindAllowed,
+		ResourceType:   "azurerm_application_gateway",
+		NestedBlock:    "sku",
+		Attribute:      "name",
+		ExpectedValues: []any{"Standard_v2", "WAF_v2"},
+	}, sku.Attribute)
+
+	zone := entries["waf_vm_2_zone"]
+	require.NotNil(t, zone.Attribute)
+	assert.Equal(t, catalog.CheckKindUnknown, zone.Attribute.Kind)
+	assert.Empty(t, zone.Attribute.ExpectedValues)
+
+	pipZones := entries["waf_pip_1_zones"]
+	require.NotNil(t, pipZones.Attribute)
+	assert.Equal(t, &catalog.Condition{
+		Attribute:   "sku",
+		Values:      []any{"Standard"},
+		Description: "`sku` is one of \"Standard\"",
+	}, pipZones.Attribute.Condition)
+	assert.Nil(t, sku.Attribute.Condition)
+
+	legacy := entries["waf_use_managed_disks_for_vm_disks_legacy_virtual_machine"]
+	assert.Nil(t, legacy.Attribute)
+	assert.Equal(t, &catalog.NotAllowedCheck{
+		ResourceType:   "azurerm_virtual_machine",
+		BlockTypes:     []string{"resource"},
+		Recommendation: "azurerm_linux_virtual_machine or azurerm_windows_virtual_machine",
+	}, legacy.NotAllowed)
+
+	assert.Equal(t, "0.1.0", entries["waf_pip_1_sku"].Since)
+	assert.Equal(t, "0.2.0", entries["waf_pip_1_azapi_sku_name"].Since)
+	assert.Equal(t, "0.2.0", legacy.Since)
+
+	zones := entries["waf_vm_2_zones"]
+	require.NotNil(t, zones.Attribute)
+	assert.Equal(t, catalog.CheckKindUnknown, zones.Attribute.Kind)
+
+	for _, e := range entries {
+		if e.Category == rules.CategoryWaf {
+			assert.Truef(t, e.Attribute != nil || e.NotAllowed != nil, "waf rule %s should describe the checked attribute or resource type", e.Name)
+			assert.NotEmptyf(t, e.AprlID, "waf rule %s should map to an APRL recommendation", e.Name)
+		}
+	}
+}
+
+func TestCatalogCheckKinds(t *testing.T) {
+	min := 7.0
+	cases := []struct {
+		desc     string
+		rule     tflint.Rule
+		expected *catalog.AttributeCheck
+	}{
+		{
+			desc: "pattern",
+			rule: attrvalue.NewPatternRule("azurerm_key_vault", "name", "^kv-", "", false, "house_kv_name"),
+			expected: &catalog.AttributeCheck{
+				Kind:         catalog.CheckKindPattern,
+				ResourceType: "azurerm_key_vault",
+				Attribute:    "name",
+				Pattern:      "^kv-",
+			},
+		},
+		{
+			desc: "range",
+			rule: attrvalue.NewRangeRule("azurerm_key_vault", "soft_delete_retention_days", attrvalue.Inclusive(min), nil, "", false, "house_kv_retention"),
+			expected: &catalog.AttributeCheck{
+				Kind:         catalog.CheckKindRange,
+				ResourceType: "azurerm_key_vault",
+				Attribute:    "soft_delete_retention_days",
+				Range:        &catalog.Range{Min: &min, Description: ">= 7"},
+			},
+		},
+		{
+			desc: "forbidden",
+			rule: attrvalue.NewForbiddenRule("azurerm_lb", "sku", []string{"Basic"}, "Standard", "", false, "house_lb_sku"),
+			expected: &catalog.AttributeCheck{
+				Kind:            catalog.CheckKindForbidden,
+				ResourceType:    "azurerm_lb",
+				Attribute:       "sku",
+				ForbiddenValues: []any{"Basic"},
+				Recommendation:  "Standard",
+			},
+		},
+		{
+			desc: "data source",
+			rule: attrvalue.On(attrvalue.NewUnknownValueRule("azurerm_key_vault_secret", "version", "", "house_secret_version"), attrvalue.BlockTypeData),
+			expected: &catalog.AttributeCheck{
+				Kind:         catalog.CheckKindUnknown,
+				BlockType:    catalog.BlockTypeData,
+				ResourceType: "azurerm_key_vault_secret",
+				Attribute:    "version",
+			},
+		},
+		{
+			desc: "azapi resource",
+			rule: attrvalue.ForArmType(attrvalue.NewSimpleRule(attrvalue.AzapiResourceType, "body.sku.name", []string{"Standard"}, "", false, "house_lb_sku"), "Microsoft.Network/loadBalancers"),
+			expected: &catalog.AttributeCheck{
+				Kind:           catalog.CheckKindAllowed,
+				ResourceType:   attrvalue.AzapiResourceType,
+				ArmType:        "Microsoft.Network/loadBalancers",
+				Attribute:      "body.sku.name",
+				ExpectedValues: []any{"Standard"},
+			},
+		},
+		{
+			desc: "required keys",
+			rule: attrvalue.NewRequiredKeysRule("azurerm_resource_group", "tags", []string{"environment"}, "", false, "house_required_tags"),
+			expected: &catalog.AttributeCheck{
+				Kind:         catalog.CheckKindRequiredKeys,
+				ResourceType: "azurerm_resource_group",
+				Attribute:    "tags",
+				Keys:         []string{"environment"},
+			},
+		},
+		{
+			desc: "forbidden keys",
+			rule: attrvalue.NewForbiddenKeysRule("azurerm_linux_web_app", "app_settings", []string{"WEBSITE_RUN_FROM_PACKAGE"}, "", false, "house_no_run_from_package"),
+			expected: &catalog.AttributeCheck{
+				Kind:         catalog.CheckKindForbiddenKeys,
+				ResourceType: "azurerm_linux_web_app",
+				Attribute:    "app_settings",
+				Keys:         []string{"WEBSITE_RUN_FROM_PACKAGE"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, catalog.NewEntry(tc.rule).Attribute)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// ModuleClass is the class of an AVM module, as indicated by the `avm-res-`, `avm-ptn-` or `avm-utl-` module name prefix.
+type ModuleClass string
+
+const (
+	ModuleClassResource ModuleClass = "resource"
+	ModuleClassPattern  ModuleClass = "pattern"
+	ModuleClassUtility  ModuleClass = "utility"
+)
+
+// ModuleClasses contains all the known module classes.
+var ModuleClasses = []ModuleClass{
+	ModuleClassResource,
+	ModuleClassPattern,
+	ModuleClassUtility,
+}
+
+// moduleClassPrefixes maps the module name prefix to the module class.
+var moduleClassPrefixes = map[string]ModuleClass{
+	"avm-res-": ModuleClassResource,
+	"avm-ptn-": ModuleClassPattern,
+	"avm-utl-": ModuleClassUtility,
+}
+
+// ParseModuleClass parses a module class from its full name (e.g. `resource`) or its short name (e.g. `res`).
+func ParseModuleClass(s string) (ModuleClass, error) {
+	s = strings.ToLower(s)
+	for prefix, class := range moduleClassPrefixes {
+		if s == string(class) || s == strings.TrimSuffix(strings.TrimPrefix(prefix, "avm-"), "-") {
+			return class, nil
+		}
+	}
+	return "", fmt.Errorf("unknown module class %q, expecting one of %v", s, ModuleClasses)
+}
+
+// moduleClassFromName returns the class of the module from a name containing the AVM module prefix,
+// e.g. `terraform-azurerm-avm-res-storage-storageaccount`.
+func moduleClassFromName(name string) (ModuleClass, bool) {
+	name = strings.ToLower(name)
+	for prefix, class := range moduleClassPrefixes {
+		if strings.Contains(name, prefix) {
+			return class, true
+		}
+	}
+	return "", false
+}
+
+// DetectModuleClass works out the module class from the given directory.
+// It looks at the name of the directory and its parents (so that examples and submodules are detected too),
+// and at the remote URLs of the git repository the directory is part of.
+func DetectModuleClass(dir string) (ModuleClass, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for {
+		if class, ok := moduleClassFromName(filepath.Base(dir)); ok {
+			return class, true
+		}
+		if config, ok := gitConfigPath(dir); ok {
+			return moduleClassFromGitConfig(config)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// gitConfigPath returns the path of the config file of the git repository whose working tree is the given directory, if it is one.
+// The .git entry is the git directory itself, or a file pointing to it with a `gitdir:` line in worktrees and submodules.
+// The git directory of a worktree shares the config of the main repository, which its commondir file points to.
+func gitConfigPath(dir string) (string, bool) {
+	gitDir := filepath.Join(dir, ".git")
+	info, err := os.Stat(gitDir)
+	if err != nil {
+		return "", false
+	}
+	if !info.IsDir() {
+		content, err := os.ReadFile(gitDir)
+		if err != nil {
+			return "", false
+		}
+		target, found := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+		if !found {
+			return "", false
+		}
+		gitDir = resolvePath(dir, strings.TrimSpace(target))
+	}
+	if commonDir, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		gitDir = resolvePath(gitDir, strings.TrimSpace(string(commonDir)))
+	}
+	return filepath.Join(gitDir, "config"), true
+}
+
+// resolvePath returns the path, joined to the base directory if it is relative.
+func resolvePath(base, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(base, path)
+}
+
+// moduleClassFromGitConfig returns the module class from the first remote url in the git config file that contains an AVM module prefix.
+func moduleClassFromGitConfig(path string) (ModuleClass, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !found || strings.TrimSpace(key) != "url" {
+			continue
+		}
+		if class, ok := moduleClassFromName(strings.TrimSpace(value)); ok {
+			return class, true
+		}
+	}
+	return "", false
+}
+
+// ModuleClassScoped is implemented by rules that only apply to some module classes.
+// Rules that do not implement it apply to all module classes.
+type ModuleClassScoped interface {
+	ModuleClasses() []ModuleClass
+}
+
+// AppliesToModuleClass reports whether the rule applies to modules of the given class.
+func AppliesToModuleClass(rule tflint.Rule, class ModuleClass) bool {
+	scoped, ok := rule.(ModuleClassScoped)
+	if !ok {
+		return true
+	}
+	return slices.Contains(scoped.ModuleClasses(), class)
+}
+
+var _ ModuleClassScoped = new(moduleClassRule)
+
+type moduleClassRule struct {
+	tflint.Rule
+	classes []ModuleClass
+}
+
+func (m *moduleClassRule) ModuleClasses() []ModuleClass {
+	return m.classes
+}
+
+func (m *moduleClassRule) Unwrap() tflint.Rule {
+	return m.Rule
+}
+
+// ForModuleClasses limits the rule to modules of the given classes.
+func ForModuleClasses(rule tflint.Rule, classes ...ModuleClass) tflint.Rule {
+	return &moduleClassRule{
+		Rule:    rule,
+		classes: classes,
+	}
+}
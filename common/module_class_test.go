@@ -0,0 +1,136 @@
+package common_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestParseModuleClass(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected common.ModuleClass
+		err      bool
+	}{
+		{input: "resource", expected: common.ModuleClassResource},
+		{input: "res", expected: common.ModuleClassResource},
+		{input: "Pattern", expected: common.ModuleClassPattern},
+		{input: "ptn", expected: common.ModuleClassPattern},
+		{input: "utility", expected: common.ModuleClassUtility},
+		{input: "utl", expected: common.ModuleClassUtility},
+		{input: "module", err: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+			class, err := common.ParseModuleClass(tc.input)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, class)
+		})
+	}
+}
+
+func TestDetectModuleClass(t *testing.T) {
+	root := t.TempDir()
+	mkdir := func(parts ...string) string {
+		dir := filepath.Join(append([]string{root}, parts...)...)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		return dir
+	}
+	gitRepo := func(name, url string) string {
+		dir := mkdir(name, ".git")
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config"), []byte("[remote \"origin\"]\n\turl = "+url+"\n"), 0o600))
+		return filepath.Dir(dir)
+	}
+	gitFile := func(name, gitdir string) string {
+		dir := mkdir(name)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: "+gitdir+"\n"), 0o600))
+		return dir
+	}
+	mainRepo := gitRepo("main", "https://github.com/Azure/terraform-azurerm-avm-res-network-virtualnetwork.git")
+	worktreeGitDir := mkdir("main", ".git", "worktrees", "feature")
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeGitDir, "commondir"), []byte("../..\n"), 0o600))
+	submoduleGitDir := mkdir("main", ".git", "modules", "child")
+	require.NoError(t, os.WriteFile(filepath.Join(submoduleGitDir, "config"), []byte("[remote \"origin\"]\n\turl = https://github.com/Azure/terraform-azurerm-avm-ptn-alz.git\n"), 0o600))
+
+	cases := []struct {
+		desc     string
+		dir      string
+		expected common.ModuleClass
+		found    bool
+	}{
+		{
+			desc:     "resource module directory",
+			dir:      mkdir("terraform-azurerm-avm-res-storage-storageaccount"),
+			expected: common.ModuleClassResource,
+			found:    true,
+		},
+		{
+			desc:     "pattern module example",
+			dir:      mkdir("terraform-azurerm-avm-ptn-aks-production", "examples", "default"),
+			expected: common.ModuleClassPattern,
+			found:    true,
+		},
+		{
+			desc:     "utility module from git remote",
+			dir:      gitRepo("checkout", "https://github.com/Azure/terraform-azure-avm-utl-regions.git"),
+			expected: common.ModuleClassUtility,
+			found:    true,
+		},
+		{
+			desc:     "git worktree",
+			dir:      gitFile("feature", worktreeGitDir),
+			expected: common.ModuleClassResource,
+			found:    true,
+		},
+		{
+			desc:     "git submodule with a relative gitdir",
+			dir:      gitFile(filepath.Join("main", "child"), filepath.Join("..", ".git", "modules", "child")),
+			expected: common.ModuleClassPattern,
+			found:    true,
+		},
+		{
+			desc:     "git repository",
+			dir:      mainRepo,
+			expected: common.ModuleClassResource,
+			found:    true,
+		},
+		{
+			desc:  "unrelated git repository",
+			dir:   gitRepo("other", "https://github.com/contoso/infra.git"),
+			found: false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			class, found := common.DetectModuleClass(tc.dir)
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.expected, class)
+		})
+	}
+}
+
+func TestForModuleClasses(t *testing.T) {
+	rule := common.ForModuleClasses(&mockRule{}, common.ModuleClassResource)
+	assert.Equal(t, "mock", rule.Name())
+	assert.True(t, common.AppliesToModuleClass(rule, common.ModuleClassResource))
+	assert.False(t, common.AppliesToModuleClass(rule, common.ModuleClassPattern))
+
+	var unscoped tflint.Rule = &mockRule{}
+	for _, class := range common.ModuleClasses {
+		assert.True(t, common.AppliesToModuleClass(unscoped, class))
+	}
+}
@@ -0,0 +1,37 @@
+package common
+
+import "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+
+var _ tflint.Rule = new(severityRule)
+
+// severityRule reports the issues of a rule with another severity, e.g. to downgrade a deprecation notice to a warning.
+type severityRule struct {
+	tflint.Rule
+	severity tflint.Severity
+}
+
+// WithSeverity returns the rule with the given severity.
+// Emit an issue with it to report that single issue with another severity than the one of the rule.
+func WithSeverity(rule tflint.Rule, severity tflint.Severity) tflint.Rule {
+	return &severityRule{
+		Rule:     rule,
+		severity: severity,
+	}
+}
+
+func (s *severityRule) Severity() tflint.Severity {
+	return s.severity
+}
+
+func (s *severityRule) Unwrap() tflint.Rule {
+	return s.Rule
+}
+
+// ReportAs returns the rule that an issue emitted by a wrapped rule should be reported under.
+// It is the wrapping rule, with the severity of the emitted rule if it was overridden with WithSeverity.
+func ReportAs(wrapper, emitted tflint.Rule) tflint.Rule {
+	if s, ok := emitted.(*severityRule); ok {
+		return WithSeverity(wrapper, s.severity)
+	}
+	return wrapper
+}
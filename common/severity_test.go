@@ -0,0 +1,19 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestReportAs(t *testing.T) {
+	wrapper := common.NewEitherCheckRule("either", true, tflint.ERROR, &mockRule{}, &mockRule{})
+
+	assert.Same(t, wrapper, common.ReportAs(wrapper, &mockRule{}))
+
+	reported := common.ReportAs(wrapper, common.WithSeverity(&mockRule{}, tflint.WARNING))
+	assert.Equal(t, "either", reported.Name())
+	assert.Equal(t, tflint.WARNING, reported.Severity())
+}
@@ -11,12 +11,14 @@ type subRunner struct {
 }
 
 type issue struct {
+	rule       tflint.Rule
 	message    string
 	issueRange hcl.Range
 }
 
 func (e *subRunner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
 	e.issues = append(e.issues, issue{
+		rule:       rule,
 		message:    message,
 		issueRange: issueRange,
 	})
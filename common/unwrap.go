@@ -0,0 +1,19 @@
+package common
+
+import "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+
+// RuleWrapper is implemented by rules that wrap another rule to change some of its behaviour.
+type RuleWrapper interface {
+	Unwrap() tflint.Rule
+}
+
+// UnwrapRule returns the innermost rule of a chain of RuleWrapper rules.
+func UnwrapRule(rule tflint.Rule) tflint.Rule {
+	for {
+		w, ok := rule.(RuleWrapper)
+		if !ok {
+			return rule
+		}
+		rule = w.Unwrap()
+	}
+}
@@ -0,0 +1,276 @@
+// Package docs generates the rule documentation: the rules table in README.md and one markdown page per rule.
+// Run `go run . docs` from the repository root after adding or changing rules.
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-avm/catalog"
+	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	// ReadmeFile is the path of the README, relative to the repository root.
+	ReadmeFile = "README.md"
+	// RulesDir is the directory of the rule pages, relative to the repository root.
+	RulesDir = "docs/rules"
+
+	tableBegin = "<!-- BEGIN_RULES_TABLE -->"
+	tableEnd   = "<!-- END_RULES_TABLE -->"
+)
+
+// Generate renders the documentation for rules.Rules and writes it to the repository at root.
+// Rule pages of rules that no longer exist are removed.
+func Generate(root string) error {
+	readme, err := os.ReadFile(filepath.Join(root, ReadmeFile))
+	if err != nil {
+		return err
+	}
+	files, err := Render(readme, rules.Rules)
+	if err != nil {
+		return err
+	}
+
+	rulesDir := filepath.Join(root, filepath.FromSlash(RulesDir))
+	if err := os.MkdirAll(rulesDir, 0o755); err != nil {
+		return err
+	}
+	existing, err := os.ReadDir(rulesDir)
+	if err != nil {
+		return err
+	}
+	for _, e := range existing {
+		if _, ok := files[path.Join(RulesDir, e.Name())]; !ok {
+			if err := os.Remove(filepath.Join(rulesDir, e.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, filepath.FromSlash(name)), content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render returns the documentation of the given rules, keyed by file path relative to the repository root.
+// The rules table of the given README content is replaced by the generated one.
+func Render(readme []byte, rs []tflint.Rule) (map[string][]byte, error) {
+	newReadme, err := renderReadme(readme, rs)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{
+		ReadmeFile: newReadme,
+	}
+	for _, rule := range rs {
+		files[RulePage(rule.Name())] = renderRulePage(rule)
+	}
+	return files, nil
+}
+
+// RulePage returns the path of the page of the named rule, relative to the repository root.
+func RulePage(ruleName string) string {
+	return path.Join(RulesDir, ruleName+".md")
+}
+
+func renderReadme(readme []byte, rs []tflint.Rule) ([]byte, error) {
+	begin := bytes.Index(readme, []byte(tableBegin))
+	end := bytes.Index(readme, []byte(tableEnd))
+	if begin < 0 || end < begin {
+		return nil, fmt.Errorf("%s must contain the %s and %s markers", ReadmeFile, tableBegin, tableEnd)
+	}
+
+	var b strings.Builder
+	b.WriteString(tableBegin + "\n")
+	b.WriteString("|Name|Description|Severity|Enabled|Link|\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, e := range catalog.Build(rs) {
+		link := ""
+		if e.Link != "" {
+			link = fmt.Sprintf("[link](%s)", e.Link)
+		}
+		fmt.Fprintf(&b, "|[%s](%s)|%s|%s|%t|%s|\n", e.Name, RulePage(e.Name), tableCell(description(e)), severity(e), e.Enabled, link)
+	}
+
+	var out bytes.Buffer
+	out.Write(readme[:begin])
+	out.WriteString(b.String())
+	out.Write(readme[end:])
+	return out.Bytes(), nil
+}
+
+func renderRulePage(rule tflint.Rule) []byte {
+	e := catalog.NewEntry(rule)
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", e.Name)
+	fmt.Fprintf(&b, "%s\n\n", description(e))
+	b.WriteString("| Property | Value |\n")
+	b.WriteString("| --- | --- |\n")
+	fmt.Fprintf(&b, "| Category | %s |\n", valueOrDash(e.Category))
+	fmt.Fprintf(&b, "| Spec ID | %s |\n", valueOrDash(e.SpecID))
+	if e.AprlID != "" {
+		fmt.Fprintf(&b, "| APRL recommendation | %s |\n", e.AprlID)
+	}
+	fmt.Fprintf(&b, "| Requirement level | %s |\n", valueOrDash(e.Level))
+	fmt.Fprintf(&b, "| Module classes | %s |\n", valueOrDash(strings.Join(e.ModuleClasses, ", ")))
+	fmt.Fprintf(&b, "| Since | %s |\n", valueOrDash(e.Since))
+	fmt.Fprintf(&b, "| Severity | %s |\n", severity(e))
+	fmt.Fprintf(&b, "| Enabled | %t |\n", e.Enabled)
+	fmt.Fprintf(&b, "| Link | %s |\n", valueOrDash(e.Link))
+
+	if c := e.Attribute; c != nil {
+		b.WriteString("\n## Checked attribute\n\n")
+		b.WriteString("| Property | Value |\n")
+		b.WriteString("| --- | --- |\n")
+		switch c.BlockType {
+		case catalog.BlockTypeData:
+			fmt.Fprintf(&b, "| Data source type | `%s` |\n", c.ResourceType)
+		case catalog.BlockTypeModule:
+			fmt.Fprintf(&b, "| Module source | `%s` |\n", c.ResourceType)
+		default:
+			fmt.Fprintf(&b, "| Resource type | `%s` |\n", c.ResourceType)
+		}
+		if c.ArmType != "" {
+			fmt.Fprintf(&b, "| ARM type | `%s` |\n", c.ArmType)
+		}
+		if c.NestedBlock != "" {
+			fmt.Fprintf(&b, "| Nested block | `%s` |\n", c.NestedBlock)
+		}
+		fmt.Fprintf(&b, "| Attribute | `%s` |\n", c.Attribute)
+		fmt.Fprintf(&b, "| Expected values | %s |\n", tableCell(expectedValues(c)))
+		if c.Condition != nil {
+			fmt.Fprintf(&b, "| Only when | %s |\n", tableCell(c.Condition.Description))
+		}
+	}
+	if c := e.NotAllowed; c != nil {
+		b.WriteString("\n## Checked resource type\n\n")
+		b.WriteString("| Property | Value |\n")
+		b.WriteString("| --- | --- |\n")
+		fmt.Fprintf(&b, "| Resource type | `%s` |\n", c.ResourceType)
+		fmt.Fprintf(&b, "| Block types | %s |\n", strings.Join(c.BlockTypes, ", "))
+		fmt.Fprintf(&b, "| Recommendation | %s |\n", valueOrDash(c.Recommendation))
+	}
+	return []byte(b.String())
+}
+
+// description returns a one-line description of the rule.
+// Attribute value rules are described by what they check, other rules by the spec item they enforce.
+func description(e catalog.Entry) string {
+	if c := e.Attribute; c != nil {
+		if c.Condition != nil {
+			return fmt.Sprintf("%s when %s", attributeDescription(c), c.Condition.Description)
+		}
+		return attributeDescription(c)
+	}
+	if c := e.NotAllowed; c != nil {
+		description := fmt.Sprintf("`%s` must not be used", c.ResourceType)
+		if c.Recommendation != "" {
+			description += fmt.Sprintf(", use %s instead", c.Recommendation)
+		}
+		return description
+	}
+	if e.SpecID != "" {
+		return fmt.Sprintf("Enforces AVM spec %s (%s)", e.SpecID, e.Level)
+	}
+	return "-"
+}
+
+// attributeDescription describes what an attribute value rule checks.
+func attributeDescription(c *catalog.AttributeCheck) string {
+	block := fmt.Sprintf("`%s`", c.ResourceType)
+	switch c.BlockType {
+	case catalog.BlockTypeData:
+		block = fmt.Sprintf("data source `%s`", c.ResourceType)
+	case catalog.BlockTypeModule:
+		block = fmt.Sprintf("calls to module `%s`", c.ResourceType)
+	}
+	if c.ArmType != "" {
+		block = fmt.Sprintf("`%s` of type `%s`", c.ResourceType, c.ArmType)
+	}
+	target := fmt.Sprintf("`%s` of %s", c.Attribute, block)
+	if c.NestedBlock != "" {
+		target = fmt.Sprintf("`%s` in the `%s` block of %s", c.Attribute, c.NestedBlock, block)
+	}
+	switch c.Kind {
+	case catalog.CheckKindUnknown:
+		return fmt.Sprintf("%s must not be set to a known value", target)
+	case catalog.CheckKindRequired:
+		return fmt.Sprintf("%s must be specified", target)
+	case catalog.CheckKindPattern:
+		return fmt.Sprintf("%s must match %s", target, expectedValues(c))
+	case catalog.CheckKindRange:
+		return fmt.Sprintf("%s must be %s", target, c.Range.Description)
+	case catalog.CheckKindForbidden:
+		return fmt.Sprintf("%s must not be one of %s", target, quotedValues(c.ForbiddenValues))
+	case catalog.CheckKindRequiredKeys:
+		return fmt.Sprintf("%s must have the keys %s", target, quotedKeys(c.Keys))
+	case catalog.CheckKindForbiddenKeys:
+		return fmt.Sprintf("%s must not have the keys %s", target, quotedKeys(c.Keys))
+	}
+	return fmt.Sprintf("%s must be one of %s", target, expectedValues(c))
+}
+
+func expectedValues(c *catalog.AttributeCheck) string {
+	switch c.Kind {
+	case catalog.CheckKindUnknown:
+		return "unknown value"
+	case catalog.CheckKindRequired:
+		return "any value"
+	case catalog.CheckKindPattern:
+		return fmt.Sprintf("`%s`", c.Pattern)
+	case catalog.CheckKindRange:
+		return c.Range.Description
+	case catalog.CheckKindForbidden:
+		s := "any value but " + quotedValues(c.ForbiddenValues)
+		if c.Recommendation != "" {
+			s += fmt.Sprintf(", e.g. `%s`", c.Recommendation)
+		}
+		return s
+	case catalog.CheckKindRequiredKeys:
+		return "any value with the keys " + quotedKeys(c.Keys)
+	case catalog.CheckKindForbiddenKeys:
+		return "any value without the keys " + quotedKeys(c.Keys)
+	}
+	return quotedValues(c.ExpectedValues)
+}
+
+func quotedValues(vs []any) string {
+	values := make([]string, 0, len(vs))
+	for _, v := range vs {
+		values = append(values, fmt.Sprintf("`%v`", v))
+	}
+	return strings.Join(values, ", ")
+}
+
+func quotedKeys(keys []string) string {
+	values := make([]any, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, k)
+	}
+	return quotedValues(values)
+}
+
+// severity returns the severity the way TFLint prints it.
+func severity(e catalog.Entry) string {
+	return strings.ToUpper(e.Severity[:1]) + e.Severity[1:]
+}
+
+// tableCell escapes the pipes of a markdown table cell, e.g. in a regular expression.
+func tableCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
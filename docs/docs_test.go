@@ -0,0 +1,44 @@
+package docs_test
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/docs"
+	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const root = ".."
+
+// TestDocsUpToDate fails when the committed documentation differs from the generated one.
+// Run `make docs` to regenerate it.
+func TestDocsUpToDate(t *testing.T) {
+	readme, err := os.ReadFile(filepath.Join(root, docs.ReadmeFile))
+	require.NoError(t, err)
+	files, err := docs.Render(readme, rules.Rules)
+	require.NoError(t, err)
+
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+		if !assert.NoErrorf(t, err, "%s is missing, run `make docs`", name) {
+			continue
+		}
+		assert.Equalf(t, string(want), string(got), "%s is out of date, run `make docs`", name)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(docs.RulesDir)))
+	require.NoError(t, err)
+	for _, e := range entries {
+		_, ok := files[path.Join(docs.RulesDir, e.Name())]
+		assert.Truef(t, ok, "%s does not belong to any rule, run `make docs`", e.Name())
+	}
+}
+
+func TestRenderRequiresMarkers(t *testing.T) {
+	_, err := docs.Render([]byte("# README\n"), rules.Rules)
+	assert.Error(t, err)
+}
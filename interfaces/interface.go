@@ -14,6 +14,7 @@ type AvmInterface struct {
 	RuleEnabled   bool            // Whether the rule is enabled by default.
 	RuleLink      string          // RuleLink to the interface specification.
 	RuleSeverity  tflint.Severity // Severity of the interface.
+	SpecVersion   string          // The spec version that introduced this definition, empty for the first spec version.
 }
 
 // StringToTypeConstraintWithDefaults converts a string to a TypeConstraintWithDefaults.
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"fmt"
 
+	"github.com/Azure/tflint-ruleset-avm/common"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/matt-FFFFFF/tfvarcheck/check"
 	"github.com/matt-FFFFFF/tfvarcheck/varcheck"
@@ -49,6 +50,9 @@ var _ tflint.Rule = new(InterfaceVarCheckRule)
 type InterfaceVarCheckRule struct {
 	tflint.DefaultRule
 	AvmInterface // This is the interface we are checking for.
+
+	olderVersions []AvmInterface // Deprecated definitions, reported as a warning when matched.
+	newerVersions []AvmInterface // Definitions of later spec versions, accepted when matched.
 }
 
 // NewVarCheckRuleFromAvmInterface returns a new rule with the given variable.
@@ -58,6 +62,18 @@ func NewVarCheckRuleFromAvmInterface(ifce AvmInterface) *InterfaceVarCheckRule {
 	}
 }
 
+// NewVarCheckRuleFromHistory returns a new rule enforcing the definition of the interface in effect at the given spec version.
+// A variable matching an older definition is reported as a deprecation warning rather than an error,
+// and a variable matching a newer definition is accepted.
+func NewVarCheckRuleFromHistory(history InterfaceHistory, specVersion string) *InterfaceVarCheckRule {
+	current, older, newer := history.At(specVersion)
+	return &InterfaceVarCheckRule{
+		AvmInterface:  current,
+		olderVersions: older,
+		newerVersions: newer,
+	}
+}
+
 // Name returns the rule name.
 func (vcr *InterfaceVarCheckRule) Name() string {
 	return vcr.RuleName
@@ -81,7 +97,51 @@ func (vcr *InterfaceVarCheckRule) Severity() tflint.Severity {
 // Check checks whether the module satisfies the interface.
 // It will search for a variable with the same name as the interface.
 // It will check the type, default value and nullable attributes.
+// When the variable does not satisfy the interface but matches another version of it,
+// a newer version is accepted and an older one is reported as a deprecation warning.
 func (vcr *InterfaceVarCheckRule) Check(r tflint.Runner) error {
+	if len(vcr.olderVersions) == 0 && len(vcr.newerVersions) == 0 {
+		return vcr.check(r)
+	}
+
+	current := &issueCollector{Runner: r}
+	if err := vcr.check(current); err != nil || len(current.issues) == 0 {
+		return err
+	}
+	for _, ifce := range vcr.newerVersions {
+		if ok, err := matches(r, ifce); err != nil || ok {
+			return err
+		}
+	}
+	for i := len(vcr.olderVersions) - 1; i >= 0; i-- {
+		ifce := vcr.olderVersions[i]
+		ok, err := matches(r, ifce)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return r.EmitIssue(
+				common.WithSeverity(vcr, tflint.WARNING),
+				fmt.Sprintf("`%s` matches spec version %s of the interface, which is deprecated. Update it to spec version %s:\n\n%s",
+					vcr.RuleName, specVersionOf(ifce), specVersionOf(vcr.AvmInterface), vcr.VarTypeString),
+				current.issues[0].issueRange,
+			)
+		}
+	}
+	return current.emitTo(r)
+}
+
+// matches reports whether the module satisfies the given definition of the interface.
+func matches(r tflint.Runner, ifce AvmInterface) (bool, error) {
+	c := &issueCollector{Runner: r}
+	if err := NewVarCheckRuleFromAvmInterface(ifce).check(c); err != nil {
+		return false, err
+	}
+	return len(c.issues) == 0, nil
+}
+
+// check checks whether the module satisfies the definition of the interface enforced by the rule.
+func (vcr *InterfaceVarCheckRule) check(r tflint.Runner) error {
 	path, err := r.GetModulePath()
 	if err != nil {
 		return err
@@ -267,3 +327,37 @@ func CheckWithReturnValue[TR any](c Checker, check func() (TR, bool, error)) (re
 		err:           err,
 	}
 }
+
+var _ tflint.Runner = new(issueCollector)
+
+// issueCollector holds on to the issues emitted through it instead of reporting them,
+// so that the interface can be checked against several definitions before reporting anything.
+type issueCollector struct {
+	tflint.Runner
+	issues []collectedIssue
+}
+
+type collectedIssue struct {
+	rule       tflint.Rule
+	message    string
+	issueRange hcl.Range
+}
+
+func (c *issueCollector) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
+	c.issues = append(c.issues, collectedIssue{
+		rule:       rule,
+		message:    message,
+		issueRange: issueRange,
+	})
+	return nil
+}
+
+// emitTo reports the collected issues to the given runner.
+func (c *issueCollector) emitTo(r tflint.Runner) error {
+	for _, issue := range c.issues {
+		if err := r.EmitIssue(issue.rule, issue.message, issue.issueRange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,21 +1,14 @@
 package interfaces
 
 import (
-	"github.com/Azure/tflint-ruleset-avm/common"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
-var Rules = []tflint.Rule{
-	NewVarCheckRuleFromAvmInterface(CustomerManagedKey),
-	NewVarCheckRuleFromAvmInterface(DiagnosticSettings),
-	NewVarCheckRuleFromAvmInterface(Location),
-	NewVarCheckRuleFromAvmInterface(Lock),
-	NewVarCheckRuleFromAvmInterface(ManagedIdentities),
-	NewVarCheckRuleFromAvmInterface(RoleAssignments),
-	NewVarCheckRuleFromAvmInterface(Tags),
-	func() tflint.Rule {
-		return common.NewEitherCheckRule("private_endpoints", true, tflint.ERROR,
-			NewVarCheckRuleFromAvmInterface(PrivateEndpoints),
-			NewVarCheckRuleFromAvmInterface(PrivateEndpointsWithSubresourceName))
-	}(),
-}
+// Rules contains the interface rules enforcing the latest spec version, see NewRules.
+var Rules = func() []tflint.Rule {
+	rules, err := NewRules(LatestSpecVersion)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}()
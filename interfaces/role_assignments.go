@@ -27,4 +27,28 @@ var RoleAssignments = AvmInterface{
 	VarTypeString: RoleAssignmentsTypeString,
 	RuleEnabled:   true,
 	RuleLink:      "https://azure.github.io/Azure-Verified-Modules/specs/shared/interfaces/#role-assignments",
+	SpecVersion:   SpecVersionPrincipalType,
+}
+
+// roleAssignmentsInitialTypeString is the type constraint string for role assignments before `principal_type` was added.
+var roleAssignmentsInitialTypeString = `map(object({
+  role_definition_id_or_name             = string
+  principal_id                           = string
+  description                            = optional(string, null)
+  skip_service_principal_aad_check       = optional(bool, false)
+  condition                              = optional(string, null)
+  condition_version                      = optional(string, null)
+  delegated_managed_identity_resource_id = optional(string, null)
+}))`
+
+// RoleAssignmentsHistory contains the successive definitions of the role assignments interface.
+var RoleAssignmentsHistory = InterfaceHistory{
+	{
+		VarCheck:      varcheck.NewVarCheck(StringToTypeConstraintWithDefaults(roleAssignmentsInitialTypeString), cty.EmptyObjectVal, false),
+		RuleName:      "role_assignments",
+		VarTypeString: roleAssignmentsInitialTypeString,
+		RuleEnabled:   true,
+		RuleLink:      "https://azure.github.io/Azure-Verified-Modules/specs/shared/interfaces/#role-assignments",
+	},
+	RoleAssignments,
 }
@@ -0,0 +1,85 @@
+package interfaces
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// The versions of the interface specification enforced by the plugin.
+// A spec version is a revision number, counting the changes made to the interface definitions in this package: "1" for the
+// definitions the plugin was first released with, then one more for each change.
+// It is not a date because the AVM specification does not publish dates, or any other version, for its interface definitions,
+// so a date could not be checked against the specification. A revision number can be checked against SpecVersions and
+// against the history of each interface, which records the revision that introduced each definition.
+// When an interface definition changes, add the next revision for the change and keep the previous definition in the history of the interface,
+// so that modules built against the previous version get a deprecation warning rather than an error.
+const (
+	SpecVersionInitial       = "1" // The interface definitions before `principal_type` was added to role assignments.
+	SpecVersionPrincipalType = "2" // `principal_type` added to role assignments, including those of private endpoints.
+)
+
+// SpecVersions contains all the spec versions, oldest first.
+var SpecVersions = []string{
+	SpecVersionInitial,
+	SpecVersionPrincipalType,
+}
+
+// LatestSpecVersion is the spec version enforced unless another one is set in the plugin config.
+var LatestSpecVersion = SpecVersions[len(SpecVersions)-1]
+
+// ValidateSpecVersion checks that the spec version is one of SpecVersions.
+func ValidateSpecVersion(specVersion string) error {
+	if !slices.Contains(SpecVersions, specVersion) {
+		return fmt.Errorf("invalid spec version %q, expecting one of %v", specVersion, SpecVersions)
+	}
+	return nil
+}
+
+// InterfaceHistory contains the successive definitions of an interface, oldest first.
+type InterfaceHistory []AvmInterface
+
+// At returns the definition in effect at the given spec version,
+// along with the definitions that preceded it and the ones that came after it.
+func (h InterfaceHistory) At(specVersion string) (current AvmInterface, older, newer []AvmInterface) {
+	i := 0
+	at := slices.Index(SpecVersions, specVersion)
+	for i < len(h)-1 && slices.Index(SpecVersions, specVersionOf(h[i+1])) <= at {
+		i++
+	}
+	return h[i], h[:i], h[i+1:]
+}
+
+// specVersionOf returns the spec version that introduced the definition.
+func specVersionOf(ifce AvmInterface) string {
+	if ifce.SpecVersion == "" {
+		return SpecVersionInitial
+	}
+	return ifce.SpecVersion
+}
+
+// NewRules returns the interface rules enforcing the definitions in effect at the given spec version.
+// Interfaces tied to the primary resource of a module only apply to resource modules.
+// The rest also apply to pattern modules, utility modules deploy nothing to attach them to.
+func NewRules(specVersion string) ([]tflint.Rule, error) {
+	if err := ValidateSpecVersion(specVersion); err != nil {
+		return nil, err
+	}
+	return []tflint.Rule{
+		common.ForModuleClasses(NewVarCheckRuleFromAvmInterface(CustomerManagedKey), common.ModuleClassResource),
+		common.ForModuleClasses(NewVarCheckRuleFromAvmInterface(DiagnosticSettings), common.ModuleClassResource),
+		common.ForModuleClasses(NewVarCheckRuleFromAvmInterface(Location), common.ModuleClassResource, common.ModuleClassPattern),
+		common.ForModuleClasses(NewVarCheckRuleFromAvmInterface(Lock), common.ModuleClassResource, common.ModuleClassPattern),
+		common.ForModuleClasses(NewVarCheckRuleFromAvmInterface(ManagedIdentities), common.ModuleClassResource),
+		common.ForModuleClasses(NewVarCheckRuleFromHistory(RoleAssignmentsHistory, specVersion), common.ModuleClassResource, common.ModuleClassPattern),
+		common.ForModuleClasses(NewVarCheckRuleFromAvmInterface(Tags), common.ModuleClassResource, common.ModuleClassPattern),
+		common.ForModuleClasses(
+			common.NewEitherCheckRule("private_endpoints", true, tflint.ERROR,
+				NewVarCheckRuleFromHistory(PrivateEndpointsHistory, specVersion),
+				NewVarCheckRuleFromHistory(PrivateEndpointsWithSubresourceNameHistory, specVersion)),
+			common.ModuleClassResource,
+		),
+	}, nil
+}
@@ -0,0 +1,98 @@
+package interfaces_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestValidateSpecVersion(t *testing.T) {
+	assert.NoError(t, interfaces.ValidateSpecVersion(interfaces.SpecVersionInitial))
+	assert.NoError(t, interfaces.ValidateSpecVersion(interfaces.SpecVersionPrincipalType))
+	assert.Error(t, interfaces.ValidateSpecVersion("0"))
+	assert.Error(t, interfaces.ValidateSpecVersion("3"))
+	assert.Error(t, interfaces.ValidateSpecVersion("2024-07-01"))
+}
+
+func TestInterfaceHistoryAt(t *testing.T) {
+	h := interfaces.RoleAssignmentsHistory
+	current, older, newer := h.At(interfaces.LatestSpecVersion)
+	assert.Equal(t, interfaces.RoleAssignments.VarTypeString, current.VarTypeString)
+	assert.Len(t, older, 1)
+	assert.Empty(t, newer)
+
+	current, older, newer = h.At(interfaces.SpecVersionInitial)
+	assert.Equal(t, h[0].VarTypeString, current.VarTypeString)
+	assert.Empty(t, older)
+	assert.Len(t, newer, 1)
+}
+
+func TestVarCheckRuleFromHistory(t *testing.T) {
+	initial := interfaces.RoleAssignmentsHistory[0]
+	cases := []struct {
+		Name        string
+		SpecVersion string
+		Content     string
+		Severity    []tflint.Severity
+		Message     string
+	}{
+		{
+			Name:        "latest definition",
+			SpecVersion: interfaces.LatestSpecVersion,
+			Content:     toTerraformVarType(interfaces.RoleAssignments),
+		},
+		{
+			Name:        "deprecated definition is a warning",
+			SpecVersion: interfaces.LatestSpecVersion,
+			Content:     toTerraformVarType(initial),
+			Severity:    []tflint.Severity{tflint.WARNING},
+			Message:     "`role_assignments` matches spec version 1 of the interface, which is deprecated. Update it to spec version 2:",
+		},
+		{
+			Name:        "pinned to the deprecated definition",
+			SpecVersion: interfaces.SpecVersionInitial,
+			Content:     toTerraformVarType(initial),
+		},
+		{
+			Name:        "newer definition is accepted when pinned",
+			SpecVersion: interfaces.SpecVersionInitial,
+			Content:     toTerraformVarType(interfaces.RoleAssignments),
+		},
+		{
+			Name:        "no definition matches",
+			SpecVersion: interfaces.LatestSpecVersion,
+			Content: `variable "role_assignments" {
+  type     = map(string)
+  default  = {}
+  nullable = false
+}`,
+			Severity: []tflint.Severity{tflint.ERROR},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			rule := interfaces.NewVarCheckRuleFromHistory(interfaces.RoleAssignmentsHistory, tc.SpecVersion)
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+
+			require.NoError(t, rule.Check(runner))
+
+			var severities []tflint.Severity
+			for _, issue := range runner.Issues {
+				assert.Equal(t, "role_assignments", issue.Rule.Name())
+				severities = append(severities, issue.Rule.Severity())
+				if tc.Message != "" {
+					assert.True(t, strings.HasPrefix(issue.Message, tc.Message), issue.Message)
+				}
+			}
+			assert.Equal(t, tc.Severity, severities)
+		})
+	}
+}
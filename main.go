@@ -1,9 +1,13 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/Azure/tflint-ruleset-avm/catalog"
+	"github.com/Azure/tflint-ruleset-avm/docs"
 	"github.com/Azure/tflint-ruleset-avm/rules"
 	"github.com/terraform-linters/tflint-plugin-sdk/plugin"
-	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 var (
@@ -16,11 +20,26 @@ var (
 )
 
 func main() {
+	// TFLint starts the plugin without arguments, subcommands are only used during development.
+	if len(os.Args) > 1 {
+		if err := run(os.Args[1]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
-		RuleSet: &tflint.BuiltinRuleSet{
-			Name:    "avm",
-			Version: version,
-			Rules:   rules.Rules,
-		},
+		RuleSet: rules.NewRuleSet("avm", version),
 	})
 }
+
+func run(subcommand string) error {
+	switch subcommand {
+	case "docs":
+		return docs.Generate(".")
+	case "catalog":
+		return catalog.Write(os.Stdout, rules.Rules)
+	}
+	return fmt.Errorf("unknown subcommand %q", subcommand)
+}
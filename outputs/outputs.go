@@ -2,8 +2,15 @@
 // Add the rules to the below slice to enable them.
 package outputs
 
-import "github.com/terraform-linters/tflint-plugin-sdk/tflint"
+import (
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
 
 var Rules = []tflint.Rule{
-	NewRequiredOutputRule("required_output_rmfr7", "resource_id", "https://azure.github.io/Azure-Verified-Modules/specs/shared/#id-rmfr7---category-outputs---minimum-required-outputs"),
+	// Only resource modules are required to output the id of their primary resource.
+	common.ForModuleClasses(
+		NewRequiredOutputRule("required_output_rmfr7", "resource_id", "https://azure.github.io/Azure-Verified-Modules/specs/shared/#id-rmfr7---category-outputs---minimum-required-outputs"),
+		common.ModuleClassResource,
+	),
 }
@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// CategoryCustom is the category of the rules declared in the `custom_rule` blocks of the plugin config.
+const CategoryCustom = "custom"
+
+// CustomRuleConfig declares an attribute value rule in a `custom_rule "<name>"` block of the plugin config,
+// so that house rules such as allowed SKUs or banned regions can be enforced without writing Go code.
+//
+// nested_block can be a path of nested blocks separated by dots, e.g. `default_node_pool.upgrade_settings`.
+// attribute can be followed by a path to a value inside a map or object attribute, e.g. `app_settings["WEBSITE_RUN_FROM_PACKAGE"]` or `identity.type`.
+//
+// The kind of rule depends on the settings:
+//   - allowed: the attribute must be one of the values, a list of lists declares allowed sets of values.
+//   - forbidden: the attribute must not be one of the values, recommendation is suggested instead.
+//   - pattern: the attribute must match the regular expression.
+//   - min and/or max: the attribute must be a number within the bounds, which are inclusive unless min_exclusive or max_exclusive is set.
+//   - unknown: the attribute must not be known, e.g. it must come from a variable without a default.
+//   - required_keys / forbidden_keys: the map or object attribute must have / must not have the keys.
+//   - not_allowed: the resource type must not be used at all, recommendation is suggested instead.
+//     It takes no attribute, block_types limits it to `resource` or `data` blocks.
+//   - must_exist on its own: the attribute must be specified. With the other kinds but unknown and not_allowed, resources that do not specify it are reported too.
+//
+// A `when` block limits the rule to the resources where another attribute has one of the values, or matches the pattern.
+// Resources where that attribute is not specified, null or unknown are skipped silently.
+// block_type makes the rule check `data` sources or `module` calls instead of resources; for module calls, resource is the module source.
+// arm_type limits the rule to the azapi resources of an ARM type, e.g. `Microsoft.Storage/storageAccounts`, whose `body` properties are checked with
+// an attribute path such as `body.sku.name`.
+type CustomRuleConfig struct {
+	Name           string               `hclext:"name,label"`
+	Resource       string               `hclext:"resource"`
+	NestedBlock    *string              `hclext:"nested_block,optional"`
+	Attribute      string               `hclext:"attribute,optional"`
+	Allowed        cty.Value            `hclext:"allowed,optional"`
+	Forbidden      cty.Value            `hclext:"forbidden,optional"`
+	Recommendation string               `hclext:"recommendation,optional"`
+	Pattern        string               `hclext:"pattern,optional"`
+	Min            *float64             `hclext:"min,optional"`
+	MinExclusive   bool                 `hclext:"min_exclusive,optional"`
+	Max            *float64             `hclext:"max,optional"`
+	MaxExclusive   bool                 `hclext:"max_exclusive,optional"`
+	Unknown        bool                 `hclext:"unknown,optional"`
+	RequiredKeys   []string             `hclext:"required_keys,optional"`
+	ForbiddenKeys  []string             `hclext:"forbidden_keys,optional"`
+	NotAllowed     bool                 `hclext:"not_allowed,optional"`
+	BlockTypes     []string             `hclext:"block_types,optional"`
+	BlockType      string               `hclext:"block_type,optional"`
+	ArmType        string               `hclext:"arm_type,optional"`
+	MustExist      bool                 `hclext:"must_exist,optional"`
+	When           *CustomRuleCondition `hclext:"when,block"`
+	Severity       string               `hclext:"severity,optional"`
+	Link           string               `hclext:"link,optional"`
+}
+
+// CustomRuleCondition is the `when` block of a custom rule, on another top-level attribute of the same resource.
+type CustomRuleCondition struct {
+	Attribute string    `hclext:"attribute"`
+	Values    cty.Value `hclext:"values,optional"`
+	Pattern   string    `hclext:"pattern,optional"`
+}
+
+// NewCustomRule returns the rule declared by the config, enabled by default.
+func NewCustomRule(c CustomRuleConfig) (tflint.Rule, error) {
+	spec := attrvalue.Spec{
+		Name:            c.Name,
+		ResourceType:    c.Resource,
+		NestedBlockType: c.NestedBlock,
+		AttributeName:   c.Attribute,
+		BlockTypes:      c.BlockTypes,
+		BlockType:       c.BlockType,
+		ArmType:         c.ArmType,
+		ExpectedValues:  c.Allowed,
+		ForbiddenValues: c.Forbidden,
+		Recommendation:  c.Recommendation,
+		Pattern:         c.Pattern,
+		Min:             attrvalue.NewBound(c.Min, c.MinExclusive),
+		Max:             attrvalue.NewBound(c.Max, c.MaxExclusive),
+		MustExist:       c.MustExist,
+		Link:            c.Link,
+	}
+	if c.When != nil {
+		condition, err := attrvalue.NewCondition(c.When.Attribute, c.When.Values, c.When.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("custom_rule %q: %w", c.Name, err)
+		}
+		spec.Condition = condition
+	}
+	hasAllowed := c.Allowed != cty.NilVal && !c.Allowed.IsNull()
+	hasForbidden := c.Forbidden != cty.NilVal && !c.Forbidden.IsNull()
+	hasPattern := c.Pattern != ""
+	hasRange := c.Min != nil || c.Max != nil
+	hasRequiredKeys := c.RequiredKeys != nil
+	hasForbiddenKeys := c.ForbiddenKeys != nil
+	switch {
+	case countTrue(hasAllowed, hasForbidden, hasPattern, hasRange, c.Unknown, c.NotAllowed, hasRequiredKeys, hasForbiddenKeys) > 1:
+		return nil, fmt.Errorf("custom_rule %q: only one of allowed, forbidden, pattern, min/max, unknown, not_allowed, required_keys or forbidden_keys can be set", c.Name)
+	case c.Recommendation != "" && !hasForbidden && !c.NotAllowed:
+		return nil, fmt.Errorf("custom_rule %q: recommendation can only be set with forbidden or not_allowed", c.Name)
+	case c.NotAllowed:
+		spec.Kind = attrvalue.KindNotAllowed
+	case hasAllowed:
+		spec.Kind = attrvalue.KindAllowed
+	case hasForbidden:
+		spec.Kind = attrvalue.KindForbidden
+	case hasPattern:
+		spec.Kind = attrvalue.KindPattern
+	case hasRange:
+		spec.Kind = attrvalue.KindRange
+	case c.Unknown:
+		spec.Kind = attrvalue.KindUnknown
+	case hasRequiredKeys:
+		spec.Kind = attrvalue.KindRequiredKeys
+		spec.Keys = c.RequiredKeys
+	case hasForbiddenKeys:
+		spec.Kind = attrvalue.KindForbiddenKeys
+		spec.Keys = c.ForbiddenKeys
+	case c.MustExist:
+		spec.Kind = attrvalue.KindRequired
+	default:
+		return nil, fmt.Errorf("custom_rule %q: one of allowed, forbidden, pattern, min/max, unknown, not_allowed, required_keys, forbidden_keys or must_exist must be set", c.Name)
+	}
+
+	rule, err := attrvalue.NewRuleFromSpec(spec)
+	if err != nil {
+		return nil, fmt.Errorf("custom_rule %q: %w", c.Name, err)
+	}
+	opts := []WrapOption{WithEnabled(true)}
+	if c.Severity != "" {
+		severity, err := parseSeverity(c.Severity)
+		if err != nil {
+			return nil, fmt.Errorf("custom_rule %q: %w", c.Name, err)
+		}
+		opts = append(opts, WithSeverity(severity))
+	}
+	return Wrap(rule, opts...), nil
+}
+
+// parseSeverity parses a severity the way it is written in the TFLint config, e.g. `warning`.
+func parseSeverity(s string) (tflint.Severity, error) {
+	for _, severity := range []tflint.Severity{tflint.ERROR, tflint.WARNING, tflint.NOTICE} {
+		if strings.EqualFold(s, severity.String()) {
+			return severity, nil
+		}
+	}
+	return tflint.ERROR, fmt.Errorf("unknown severity %q, expecting one of error, warning or notice", s)
+}
+
+// countTrue returns the number of true values.
+func countTrue(bs ...bool) int {
+	n := 0
+	for _, b := range bs {
+		if b {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,471 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/prashantv/gostub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// customRule applies the plugin config to a new ruleset and returns the named rule.
+func customRule(t *testing.T, pluginConfig, name string) tflint.Rule {
+	rs, err := applyRuleSetConfig(t, &tflint.Config{}, pluginConfig)
+	require.NoError(t, err)
+	for _, rule := range rs.EnabledRules {
+		if rule.Name() == name {
+			return rule
+		}
+	}
+	t.Fatalf("rule %s is not enabled", name)
+	return nil
+}
+
+func TestCustomRules(t *testing.T) {
+	cases := []struct {
+		desc     string
+		name     string
+		config   string
+		content  string
+		severity tflint.Severity
+		messages []string
+	}{
+		{
+			desc: "allowed values",
+			name: "house_storage_replication",
+			config: `custom_rule "house_storage_replication" {
+  resource  = "azurerm_storage_account"
+  attribute = "account_replication_type"
+  allowed   = ["ZRS", "GZRS"]
+}`,
+			content: `resource "azurerm_storage_account" "this" {
+  account_replication_type = "LRS"
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"LRS is an invalid attribute value of `account_replication_type` - expecting (one of) [ZRS GZRS]"},
+		},
+		{
+			desc: "allowed values in nested block with warning severity",
+			name: "house_agw_sku",
+			config: `custom_rule "house_agw_sku" {
+  resource     = "azurerm_application_gateway"
+  nested_block = "sku"
+  attribute    = "tier"
+  allowed      = ["WAF_v2"]
+  must_exist   = true
+  severity     = "warning"
+}`,
+			content: `resource "azurerm_application_gateway" "this" {
+  sku {
+    name = "WAF_v2"
+  }
+}`,
+			severity: tflint.WARNING,
+			messages: []string{"The attribute `tier` must be specified"},
+		},
+		{
+			desc: "allowed values in a block path",
+			name: "house_aks_max_surge",
+			config: `custom_rule "house_aks_max_surge" {
+  resource     = "azurerm_kubernetes_cluster"
+  nested_block = "default_node_pool.upgrade_settings"
+  attribute    = "max_surge"
+  allowed      = ["33%"]
+}`,
+			content: `resource "azurerm_kubernetes_cluster" "this" {
+  default_node_pool {
+    upgrade_settings {
+      max_surge = "10%"
+    }
+  }
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"10% is an invalid attribute value of `max_surge` - expecting (one of) [33%]"},
+		},
+		{
+			desc: "allowed sets",
+			name: "house_zones",
+			config: `custom_rule "house_zones" {
+  resource  = "azurerm_public_ip"
+  attribute = "zones"
+  allowed   = [[1, 2, 3]]
+}`,
+			content: `resource "azurerm_public_ip" "this" {
+  zones = [3, 2, 1]
+}`,
+		},
+		{
+			desc: "unknown value",
+			name: "house_location",
+			config: `custom_rule "house_location" {
+  resource  = "azurerm_resource_group"
+  attribute = "location"
+  unknown   = true
+  severity  = "notice"
+}`,
+			content: `resource "azurerm_resource_group" "this" {
+  location = "westeurope"
+}`,
+			severity: tflint.NOTICE,
+			messages: []string{"invalid attribute value of `location` - expecting unknown"},
+		},
+		{
+			desc: "pattern",
+			name: "house_key_vault_name",
+			config: `custom_rule "house_key_vault_name" {
+  resource  = "azurerm_key_vault"
+  attribute = "name"
+  pattern   = "^kv-"
+}`,
+			content: `resource "azurerm_key_vault" "this" {
+  name = "vault-prod"
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"vault-prod is an invalid attribute value of `name` - expecting a value matching `^kv-`"},
+		},
+		{
+			desc: "forbidden values",
+			name: "house_min_tls",
+			config: `custom_rule "house_min_tls" {
+  resource       = "azurerm_mssql_server"
+  attribute      = "minimum_tls_version"
+  forbidden      = ["1.0", "1.1"]
+  recommendation = "1.2"
+}`,
+			content: `resource "azurerm_mssql_server" "this" {
+  minimum_tls_version = "1.0"
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"1.0 is a forbidden attribute value of `minimum_tls_version` - use 1.2 instead"},
+		},
+		{
+			desc: "required keys",
+			name: "house_required_tags",
+			config: `custom_rule "house_required_tags" {
+  resource      = "azurerm_resource_group"
+  attribute     = "tags"
+  required_keys = ["environment", "owner"]
+}`,
+			content: `resource "azurerm_resource_group" "this" {
+  tags = {
+    environment = "prod"
+  }
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"The key `owner` of `tags` must be specified"},
+		},
+		{
+			desc: "forbidden keys",
+			name: "house_no_run_from_package",
+			config: `custom_rule "house_no_run_from_package" {
+  resource       = "azurerm_linux_web_app"
+  attribute      = "app_settings"
+  forbidden_keys = ["WEBSITE_RUN_FROM_PACKAGE"]
+}`,
+			content: `resource "azurerm_linux_web_app" "this" {
+  app_settings = {
+    WEBSITE_RUN_FROM_PACKAGE = "1"
+  }
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"The key `WEBSITE_RUN_FROM_PACKAGE` of `app_settings` is forbidden"},
+		},
+		{
+			desc: "value at a path inside an attribute",
+			name: "house_identity_type",
+			config: `custom_rule "house_identity_type" {
+  resource  = "azapi_resource"
+  attribute = "identity.type"
+  allowed   = ["SystemAssigned"]
+}`,
+			content: `resource "azapi_resource" "this" {
+  identity = {
+    type = "UserAssigned"
+  }
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"UserAssigned is an invalid attribute value of `identity.type` - expecting (one of) [SystemAssigned]"},
+		},
+		{
+			desc: "module call argument",
+			name: "house_lb_module_sku",
+			config: `custom_rule "house_lb_module_sku" {
+  block_type = "module"
+  resource   = "Azure/avm-res-network-loadbalancer/azurerm"
+  attribute  = "sku"
+  allowed    = ["Standard"]
+}`,
+			content: `module "lb" {
+  source = "Azure/avm-res-network-loadbalancer/azurerm"
+  sku    = "Basic"
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]"},
+		},
+		{
+			desc: "azapi resource body",
+			name: "house_storage_sku",
+			config: `custom_rule "house_storage_sku" {
+  resource  = "azapi_resource"
+  arm_type  = "Microsoft.Storage/storageAccounts"
+  attribute = "body.sku.name"
+  allowed   = ["Standard_ZRS", "Standard_GZRS"]
+}`,
+			content: `resource "azapi_resource" "this" {
+  type = "Microsoft.Storage/storageAccounts@2023-01-01"
+  body = {
+    sku = { name = "Standard_LRS" }
+  }
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"Standard_LRS is an invalid attribute value of `body.sku.name` - expecting (one of) [Standard_ZRS Standard_GZRS]"},
+		},
+		{
+			desc: "not allowed resource type",
+			name: "house_no_classic_sql",
+			config: `custom_rule "house_no_classic_sql" {
+  resource       = "azurerm_sql_server"
+  not_allowed    = true
+  recommendation = "azurerm_mssql_server"
+}`,
+			content: `resource "azurerm_sql_server" "this" {
+  name = "example"
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"The resource type `azurerm_sql_server` is not allowed - use azurerm_mssql_server instead"},
+		},
+		{
+			desc: "condition",
+			name: "house_premium_zones",
+			config: `custom_rule "house_premium_zones" {
+  resource  = "azurerm_container_registry"
+  attribute = "zone_redundancy_enabled"
+  allowed   = [true]
+
+  when {
+    attribute = "sku"
+    values    = ["Premium"]
+  }
+}`,
+			content: `resource "azurerm_container_registry" "basic" {
+  sku                     = "Basic"
+  zone_redundancy_enabled = false
+}
+
+resource "azurerm_container_registry" "premium" {
+  sku                     = "Premium"
+  zone_redundancy_enabled = false
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"false is an invalid attribute value of `zone_redundancy_enabled` - expecting (one of) [true]"},
+		},
+		{
+			desc: "range",
+			name: "house_kv_retention",
+			config: `custom_rule "house_kv_retention" {
+  resource  = "azurerm_key_vault"
+  attribute = "soft_delete_retention_days"
+  min       = 30
+  max       = 90
+}`,
+			content: `resource "azurerm_key_vault" "this" {
+  soft_delete_retention_days = 7
+}`,
+			severity: tflint.ERROR,
+			messages: []string{"7 is an invalid attribute value of `soft_delete_retention_days` - expecting a value >= 30"},
+		},
+		{
+			desc: "must exist",
+			name: "house_min_tls",
+			config: `custom_rule "house_min_tls" {
+  resource   = "azurerm_storage_account"
+  attribute  = "min_tls_version"
+  must_exist = true
+}`,
+			content: `resource "azurerm_storage_account" "this" {
+  min_tls_version = "TLS1_2"
+}`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			rule := customRule(t, tc.config, tc.name)
+			md, ok := rules.MetadataOf(tc.name)
+			require.True(t, ok)
+			assert.Equal(t, rules.CategoryCustom, md.Category)
+
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			require.NoError(t, rule.Check(runner))
+
+			var messages []string
+			for _, issue := range runner.Issues {
+				assert.Equal(t, rule.Name(), issue.Rule.Name())
+				assert.Equal(t, tc.severity, issue.Rule.Severity())
+				messages = append(messages, issue.Message)
+			}
+			assert.Equal(t, tc.messages, messages)
+		})
+	}
+}
+
+func TestCustomRuleErrors(t *testing.T) {
+	cases := []struct {
+		desc   string
+		config string
+	}{
+		{
+			desc: "no check",
+			config: `custom_rule "house_sku" {
+  resource  = "azurerm_lb"
+  attribute = "sku"
+}`,
+		},
+		{
+			desc: "allowed and unknown",
+			config: `custom_rule "house_sku" {
+  resource  = "azurerm_lb"
+  attribute = "sku"
+  allowed   = ["Standard"]
+  unknown   = true
+}`,
+		},
+		{
+			desc: "allowed and pattern",
+			config: `custom_rule "house_sku" {
+  resource  = "azurerm_lb"
+  attribute = "sku"
+  allowed   = ["Standard"]
+  pattern   = "^Standard$"
+}`,
+		},
+		{
+			desc: "invalid pattern",
+			config: `custom_rule "house_sku" {
+  resource  = "azurerm_lb"
+  attribute = "sku"
+  pattern   = "Standard("
+}`,
+		},
+		{
+			desc: "recommendation without forbidden values",
+			config: `custom_rule "house_sku" {
+  resource       = "azurerm_lb"
+  attribute      = "sku"
+  allowed        = ["Standard"]
+  recommendation = "Standard"
+}`,
+		},
+		{
+			desc: "not allowed with an attribute",
+			config: `custom_rule "house_sku" {
+  resource    = "azurerm_lb"
+  attribute   = "sku"
+  not_allowed = true
+}`,
+		},
+		{
+			desc: "required and forbidden keys",
+			config: `custom_rule "house_tags" {
+  resource       = "azurerm_resource_group"
+  attribute      = "tags"
+  required_keys  = ["environment"]
+  forbidden_keys = ["env"]
+}`,
+		},
+		{
+			desc: "empty required keys",
+			config: `custom_rule "house_tags" {
+  resource      = "azurerm_resource_group"
+  attribute     = "tags"
+  required_keys = []
+}`,
+		},
+		{
+			desc: "invalid attribute path",
+			config: `custom_rule "house_run_from_package" {
+  resource  = "azurerm_linux_web_app"
+  attribute = "app_settings[\"WEBSITE_RUN_FROM_PACKAGE\""
+  allowed   = ["1"]
+}`,
+		},
+		{
+			desc: "no attribute",
+			config: `custom_rule "house_sku" {
+  resource = "azurerm_lb"
+  allowed  = ["Standard"]
+}`,
+		},
+		{
+			desc: "condition without values or pattern",
+			config: `custom_rule "house_sku" {
+  resource  = "azurerm_lb"
+  attribute = "sku"
+  allowed   = ["Standard"]
+
+  when {
+    attribute = "name"
+  }
+}`,
+		},
+		{
+			desc: "empty range",
+			config: `custom_rule "house_sku" {
+  resource      = "azurerm_search_service"
+  attribute     = "replica_count"
+  min           = 3
+  max           = 3
+  max_exclusive = true
+}`,
+		},
+		{
+			desc: "unknown severity",
+			config: `custom_rule "house_sku" {
+  resource  = "azurerm_lb"
+  attribute = "sku"
+  allowed   = ["Standard"]
+  severity  = "fatal"
+}`,
+		},
+		{
+			desc: "empty allowed values",
+			config: `custom_rule "house_sku" {
+  resource  = "azurerm_lb"
+  attribute = "sku"
+  allowed   = []
+}`,
+		},
+		{
+			desc: "ARM type on an azurerm resource",
+			config: `custom_rule "house_storage_sku" {
+  resource  = "azurerm_storage_account"
+  arm_type  = "Microsoft.Storage/storageAccounts"
+  attribute = "account_replication_type"
+  allowed   = ["ZRS"]
+}`,
+		},
+		{
+			desc: "duplicate of a builtin rule",
+			config: `custom_rule "waf_pip_1_sku" {
+  resource  = "azurerm_public_ip"
+  attribute = "sku"
+  allowed   = ["Standard"]
+}`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			_, err := applyRuleSetConfig(t, &tflint.Config{}, tc.config)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package rules
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// RequirementLevel is the RFC 2119 keyword used by the AVM specification for a requirement.
+type RequirementLevel string
+
+const (
+	LevelMust   RequirementLevel = "MUST"
+	LevelShould RequirementLevel = "SHOULD"
+	LevelMay    RequirementLevel = "MAY"
+	// LevelNone is the level of the rules that enforce no spec item, e.g. style rules wrapped from other rulesets.
+	// They keep the severity of the rule.
+	LevelNone RequirementLevel = "NONE"
+)
+
+// Metadata describes how a rule maps onto the AVM specification.
+type Metadata struct {
+	SpecID        string               // e.g. "TFFR1", empty if the rule does not map onto a single spec item.
+	Level         RequirementLevel     // The requirement level of the spec item, LevelNone if the rule enforces none.
+	Category      string               // The category of the rule, see Categories.
+	ModuleClasses []common.ModuleClass // The module classes the rule applies to.
+	Since         string               // The plugin version the rule was introduced in.
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Metadata{}
+)
+
+// Register records the metadata of the rule in the registry and returns the rule with the metadata applied:
+// issue messages are prefixed with the spec ID, and SHOULD and MAY level rules report warnings.
+// A rule without a level is registered at the MUST level.
+// When no module classes are given, they are taken from the rule if it is common.ModuleClassScoped,
+// otherwise the rule applies to all module classes.
+func Register(rule tflint.Rule, md Metadata) tflint.Rule {
+	if md.Level == "" {
+		md.Level = LevelMust
+	}
+	if md.ModuleClasses == nil {
+		md.ModuleClasses = common.ModuleClasses
+		if scoped, ok := rule.(common.ModuleClassScoped); ok {
+			md.ModuleClasses = scoped.ModuleClasses()
+		}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[rule.Name()] = md
+	return &registeredRule{
+		Rule:     rule,
+		metadata: md,
+	}
+}
+
+// registerAll registers all the rules of a category with the same metadata.
+func registerAll(category string, md Metadata, rules ...tflint.Rule) []tflint.Rule {
+	md.Category = category
+	registered := make([]tflint.Rule, 0, len(rules))
+	for _, rule := range rules {
+		registered = append(registered, Register(rule, md))
+	}
+	return registered
+}
+
+// MetadataOf returns the metadata of the named rule.
+func MetadataOf(ruleName string) (Metadata, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	md, ok := registry[ruleName]
+	return md, ok
+}
+
+// RegisteredRuleNames returns the sorted names of all the registered rules.
+func RegisteredRuleNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var _ tflint.Rule = new(registeredRule)
+var _ common.ModuleClassScoped = new(registeredRule)
+
+// registeredRule applies the registered metadata to a rule.
+type registeredRule struct {
+	tflint.Rule
+	metadata Metadata
+}
+
+func (r *registeredRule) Severity() tflint.Severity {
+	switch r.metadata.Level {
+	case LevelMust, LevelNone:
+		return r.Rule.Severity()
+	}
+	return tflint.WARNING
+}
+
+func (r *registeredRule) Unwrap() tflint.Rule {
+	return r.Rule
+}
+
+func (r *registeredRule) ModuleClasses() []common.ModuleClass {
+	return r.metadata.ModuleClasses
+}
+
+// Metadata returns the registered Metadata of the rule.
+func (r *registeredRule) Metadata() interface{} {
+	return r.metadata
+}
+
+// Check runs the rule, unless it does not apply to the module class detected for the runner.
+func (r *registeredRule) Check(runner tflint.Runner) error {
+	if detected, ok := runner.(*moduleClassRunner); ok && !common.AppliesToModuleClass(r, detected.class) {
+		return nil
+	}
+	prefix := ""
+	if r.metadata.SpecID != "" {
+		prefix = r.metadata.SpecID + ": "
+	}
+	return r.Rule.Check(&issueRunner{
+		Runner: runner,
+		rule:   r,
+		prefix: prefix,
+	})
+}
+
+var _ tflint.Runner = new(issueRunner)
+
+// issueRunner re-emits the issues of a wrapped rule as issues of the wrapping rule,
+// so that the name, severity and link of the wrapping rule are reported.
+// Severities overridden with common.WithSeverity are kept.
+type issueRunner struct {
+	tflint.Runner
+	rule   tflint.Rule
+	prefix string
+}
+
+func (r *issueRunner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
+	return r.Runner.EmitIssue(common.ReportAs(r.rule, rule), r.prefix+message, issueRange)
+}
+
+func (r *issueRunner) EmitIssueWithFix(rule tflint.Rule, message string, issueRange hcl.Range, fixFunc func(f tflint.Fixer) error) error {
+	return r.Runner.EmitIssueWithFix(common.ReportAs(r.rule, rule), r.prefix+message, issueRange, fixFunc)
+}
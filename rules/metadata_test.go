@@ -0,0 +1,151 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = new(issueRule)
+
+// issueRule always emits a single issue.
+type issueRule struct {
+	tflint.DefaultRule
+	name string
+}
+
+func (r *issueRule) Name() string {
+	return r.name
+}
+
+func (r *issueRule) Enabled() bool {
+	return true
+}
+
+func (r *issueRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *issueRule) Check(runner tflint.Runner) error {
+	return runner.EmitIssue(r, "something is wrong", hcl.Range{})
+}
+
+func TestAllRulesHaveMetadata(t *testing.T) {
+	for _, c := range rules.Categories {
+		for _, rule := range c.Rules {
+			md, ok := rules.MetadataOf(rule.Name())
+			if !assert.Truef(t, ok, "rule %s is not registered", rule.Name()) {
+				continue
+			}
+			assert.Equal(t, c.Name, md.Category, rule.Name())
+			assert.NotEmpty(t, md.Level, rule.Name())
+			assert.NotEmpty(t, md.ModuleClasses, rule.Name())
+			assert.NotEmpty(t, md.Since, rule.Name())
+			assert.Equal(t, md, rule.Metadata(), rule.Name())
+		}
+	}
+}
+
+func TestRegisteredRuleSeverity(t *testing.T) {
+	for _, rule := range rules.Rules {
+		md, _ := rules.MetadataOf(rule.Name())
+		if md.Level == rules.LevelShould {
+			assert.Equal(t, tflint.WARNING, rule.Severity(), rule.Name())
+		}
+	}
+
+	md, ok := rules.MetadataOf("terraform_heredoc_usage")
+	require.True(t, ok)
+	assert.Equal(t, rules.LevelNone, md.Level)
+	assert.Empty(t, md.SpecID)
+	for _, rule := range rules.Rules {
+		if rule.Name() == "terraform_heredoc_usage" {
+			assert.Equal(t, tflint.NOTICE, rule.Severity())
+		}
+	}
+
+	for _, c := range rules.Categories {
+		if c.Name != rules.CategoryOutputs {
+			continue
+		}
+		assert.Equal(t, tflint.ERROR, c.Rules[0].Severity())
+	}
+}
+
+func TestRegisteredRuleSpecIDs(t *testing.T) {
+	expected := map[string]string{
+		"customer_managed_key": "RMFR5",
+		"role_assignments":     "RMFR5",
+		"location":             "",
+		"lock":                 "",
+		"tags":                 "",
+		"tfnfr26":              "TFNFR26",
+		"terraform_required_providers_declaration": "",
+	}
+	for name, specID := range expected {
+		md, ok := rules.MetadataOf(name)
+		require.True(t, ok, name)
+		assert.Equal(t, specID, md.SpecID, name)
+		if specID == "" {
+			assert.Equal(t, rules.LevelNone, md.Level, name)
+		}
+	}
+}
+
+func TestRegisteredRuleModuleClasses(t *testing.T) {
+	md, ok := rules.MetadataOf("required_output_rmfr7")
+	require.True(t, ok)
+	assert.Equal(t, []common.ModuleClass{common.ModuleClassResource}, md.ModuleClasses)
+
+	md, ok = rules.MetadataOf("required_module_source_tffr1")
+	require.True(t, ok)
+	assert.Equal(t, common.ModuleClasses, md.ModuleClasses)
+}
+
+func TestRegisteredRuleIssues(t *testing.T) {
+	cases := []struct {
+		desc     string
+		metadata rules.Metadata
+		message  string
+		severity tflint.Severity
+	}{
+		{
+			desc:     "must level with spec id",
+			metadata: rules.Metadata{SpecID: "TFNFR99", Level: rules.LevelMust},
+			message:  "TFNFR99: something is wrong",
+			severity: tflint.ERROR,
+		},
+		{
+			desc:     "should level with spec id",
+			metadata: rules.Metadata{SpecID: "SFR99", Level: rules.LevelShould},
+			message:  "SFR99: something is wrong",
+			severity: tflint.WARNING,
+		},
+		{
+			desc:     "no spec id",
+			metadata: rules.Metadata{Level: rules.LevelMust},
+			message:  "something is wrong",
+			severity: tflint.ERROR,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			rule := rules.Register(&issueRule{name: "issue_rule_" + tc.desc}, tc.metadata)
+			runner := helper.TestRunner(t, map[string]string{"main.tf": ""})
+			require.NoError(t, rule.Check(runner))
+			require.Len(t, runner.Issues, 1)
+			assert.Equal(t, tc.message, runner.Issues[0].Message)
+			assert.Equal(t, rule, runner.Issues[0].Rule)
+			assert.Equal(t, tc.severity, runner.Issues[0].Rule.Severity())
+		})
+	}
+}
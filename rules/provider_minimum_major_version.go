@@ -20,6 +20,21 @@ type ProviderVersionRule struct {
 	VersionsShouldFailed         []string
 }
 
+// ProviderVersionConfig declares an additional provider version rule in the `provider_version "<name>"` block of the plugin config.
+type ProviderVersionConfig struct {
+	Name               string   `hclext:"name,label"`
+	Source             string   `hclext:"source,optional"`
+	Recommended        string   `hclext:"recommended,optional"`
+	VersionsShouldFail []string `hclext:"versions_should_fail,optional"`
+}
+
+// ProviderVersionRuleConfig overrides the defaults of a provider version rule in its `rule "provider_<name>_version"` block.
+type ProviderVersionRuleConfig struct {
+	Source             string   `hclext:"source,optional"`
+	Recommended        string   `hclext:"recommended,optional"`
+	VersionsShouldFail []string `hclext:"versions_should_fail,optional"`
+}
+
 func NewProviderVersionRule(providerName, providerSource, recommendedVersion string, versionsShouldFailed []string) *ProviderVersionRule {
 	return &ProviderVersionRule{
 		ProviderName:                 providerName,
@@ -41,7 +56,24 @@ func (m *ProviderVersionRule) Severity() tflint.Severity {
 	return tflint.ERROR
 }
 
+// config returns the rule settings, with any values declared in the rule block taking precedence over the defaults.
+func (m *ProviderVersionRule) config(r tflint.Runner) (*ProviderVersionRuleConfig, error) {
+	config := &ProviderVersionRuleConfig{
+		Source:             m.ProviderSource,
+		Recommended:        m.RecommendedVersionConstraint,
+		VersionsShouldFail: m.VersionsShouldFailed,
+	}
+	if err := r.DecodeRuleConfig(m.Name(), config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 func (m *ProviderVersionRule) Check(r tflint.Runner) error {
+	config, err := m.config(r)
+	if err != nil {
+		return err
+	}
 	content, err := r.GetModuleContent(&hclext.BodySchema{
 		Blocks: []hclext.BlockSchema{
 			{
@@ -90,15 +122,15 @@ func (m *ProviderVersionRule) Check(r tflint.Runner) error {
 			if err = r.EvaluateExpr(providerAttr.Expr, &provider, &tflint.EvaluateExprOption{WantType: &wantType}); err != nil {
 				return err
 			}
-			if !strings.EqualFold(provider.Source, m.ProviderSource) {
-				return r.EmitIssue(m, fmt.Sprintf("provider `%s`'s source should be %s, got %s", m.ProviderName, m.ProviderSource, provider.Source), providerAttr.Range)
+			if !strings.EqualFold(provider.Source, config.Source) {
+				return r.EmitIssue(m, fmt.Sprintf("provider `%s`'s source should be %s, got %s", m.ProviderName, config.Source, provider.Source), providerAttr.Range)
 			}
 			constraint, err := goverison.NewConstraint(provider.Version)
 			if err != nil {
 				return err
 			}
 			var versionsShouldFailed []*goverison.Version
-			for _, v := range m.VersionsShouldFailed {
+			for _, v := range config.VersionsShouldFail {
 				testVersion, err := goverison.NewVersion(v)
 				if err != nil {
 					return err
@@ -107,7 +139,11 @@ func (m *ProviderVersionRule) Check(r tflint.Runner) error {
 			}
 			for i, v := range versionsShouldFailed {
 				if constraint.Check(v) {
-					return r.EmitIssue(m, fmt.Sprintf("this module should not support provider `%s` version %s, recommended version constraint: %s", m.ProviderName, m.VersionsShouldFailed[i], m.RecommendedVersionConstraint), providerAttr.Range)
+					message := fmt.Sprintf("this module should not support provider `%s` version %s", m.ProviderName, config.VersionsShouldFail[i])
+					if config.Recommended != "" {
+						message += fmt.Sprintf(", recommended version constraint: %s", config.Recommended)
+					}
+					return r.EmitIssue(m, message, providerAttr.Range)
 				}
 			}
 		}
@@ -166,6 +166,26 @@ func TestModtmProviderVersionRule(t *testing.T) {
 			}),
 			expected: helper.Issues{},
 		},
+		{
+			desc: "no recommended constraint",
+			config: `terraform {
+  required_providers {
+    modtm = {
+      source = "Azure/modtm"
+      version = ">= 0.3.0"
+    }
+  }
+}`,
+			rule: rules.NewProviderVersionRule("modtm", "Azure/modtm", "", []string{
+				"1.0.0",
+			}),
+			expected: helper.Issues{
+				{
+					Rule:    rules.NewProviderVersionRule("modtm", "Azure/modtm", "", []string{"1.0.0"}),
+					Message: "this module should not support provider `modtm` version 1.0.0",
+				},
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.desc, func(t *testing.T) {
@@ -185,3 +205,84 @@ func mockFs(c string) afero.Afero {
 	_ = afero.WriteFile(fs, "main.tf", []byte(c), os.ModePerm)
 	return afero.Afero{Fs: fs}
 }
+
+func TestProviderVersionRuleConfig(t *testing.T) {
+	cases := []struct {
+		desc     string
+		config   string
+		tflint   string
+		expected helper.Issues
+	}{
+		{
+			desc: "rule block overrides recommended constraint and failing versions",
+			config: `terraform {
+  required_providers {
+    modtm = {
+      source  = "Azure/modtm"
+      version = "~> 0.3.0"
+    }
+  }
+}`,
+			tflint: `rule "provider_modtm_version" {
+  enabled              = true
+  recommended          = "~> 0.4"
+  versions_should_fail = ["0.3.999", "1.0.0"]
+}`,
+			expected: helper.Issues{
+				{
+					Rule:    rules.NewProviderVersionRule("modtm", "Azure/modtm", "~> 0.3", nil),
+					Message: "this module should not support provider `modtm` version 0.3.999, recommended version constraint: ~> 0.4",
+				},
+			},
+		},
+		{
+			desc: "rule block overrides source",
+			config: `terraform {
+  required_providers {
+    modtm = {
+      source  = "Azure/modtm"
+      version = "~> 0.3.0"
+    }
+  }
+}`,
+			tflint: `rule "provider_modtm_version" {
+  enabled = true
+  source  = "contoso/modtm"
+}`,
+			expected: helper.Issues{
+				{
+					Rule:    rules.NewProviderVersionRule("modtm", "Azure/modtm", "~> 0.3", nil),
+					Message: "provider `modtm`'s source should be contoso/modtm, got Azure/modtm",
+				},
+			},
+		},
+		{
+			desc: "rule block without settings keeps defaults",
+			config: `terraform {
+  required_providers {
+    modtm = {
+      source  = "Azure/modtm"
+      version = "~> 0.3.0"
+    }
+  }
+}`,
+			tflint: `rule "provider_modtm_version" {
+  enabled = true
+}`,
+			expected: helper.Issues{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": c.config, ".tflint.hcl": c.tflint})
+			rule := rules.NewProviderVersionRule("modtm", "Azure/modtm", "~> 0.3", []string{
+				"0.2.999",
+				"1.0.0",
+			})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, c.expected, runner.Issues)
+		})
+	}
+}
@@ -1,8 +1,6 @@
 package rules
 
 import (
-	"slices"
-
 	"github.com/Azure/tflint-ruleset-avm/interfaces"
 	"github.com/Azure/tflint-ruleset-avm/outputs"
 	"github.com/Azure/tflint-ruleset-avm/waf"
@@ -11,41 +9,226 @@ import (
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
-var Rules = func() []tflint.Rule {
-	return slices.Concat(
-		[]tflint.Rule{
-			Wrap(basic.NewTerraformHeredocUsageRule()),
-			Wrap(basic.NewTerraformModuleProviderDeclarationRule()),
-			Wrap(basic.NewTerraformOutputSeparateRule()),
-			Wrap(basic.NewTerraformRequiredProvidersDeclarationRule()),
-			Wrap(basic.NewTerraformRequiredVersionDeclarationRule()),
-			Wrap(basic.NewTerraformSensitiveVariableNoDefaultRule()),
-			Wrap(basic.NewTerraformVariableNullableFalseRule()),
-			Wrap(basic.NewTerraformVariableSeparateRule()),
-			Wrap(azurerm.NewAzurermResourceTagRule()),
-			NewTerraformDotTfRule(),
-			NewModuleSourceRule(),
-			NewProviderVersionRule("modtm", "Azure/modtm", "~> 0.3", []string{
+// The rule categories shipped by the plugin.
+// Apart from CategoryRules, each category can be toggled as a whole in the plugin config.
+const (
+	CategoryRules      = "rules"
+	CategoryWaf        = "waf"
+	CategoryInterfaces = "interfaces"
+	CategoryOutputs    = "outputs"
+)
+
+// Links to the AVM Terraform specification, used for the wrapped rules that enforce a spec item.
+const (
+	terraformSpecLink = "https://azure.github.io/Azure-Verified-Modules/specs/terraform/"
+	tfnfr21Link       = terraformSpecLink + "#id-tfnfr21---category-code-style---discourage-nullability-by-default"
+	tfnfr25Link       = terraformSpecLink + "#id-tfnfr25---category-code-style---verified-modules-requirements"
+	tfnfr26Link       = terraformSpecLink + "#id-tfnfr26---category-code-style---providers-must-be-declared-in-the-required_providers-block-in-terraformtf-and-must-have-a-constraint-on-minimum-and-maximum-major-version"
+	tfnfr27Link       = terraformSpecLink + "#id-tfnfr27---category-code-style---provider-declarations-in-modules"
+)
+
+// Category is a named group of rules.
+type Category struct {
+	Name  string
+	Rules []tflint.Rule
+}
+
+// Categories contains all the rules of the plugin, grouped by category.
+// Every rule is registered with its metadata, see Register.
+var Categories = []Category{
+	{
+		Name: CategoryRules,
+		Rules: []tflint.Rule{
+			Register(Wrap(basic.NewTerraformHeredocUsageRule()), noSpecMetadata("0.1.0")),
+			Register(Wrap(basic.NewTerraformModuleProviderDeclarationRule(), WithLink(tfnfr27Link), WithSeverity(tflint.ERROR)), coreMetadata("TFNFR27", "0.1.0")),
+			Register(Wrap(basic.NewTerraformOutputSeparateRule()), noSpecMetadata("0.1.0")),
+			// TFNFR26 is registered for the tfnfr26 rule, this one only covers the required_providers part of the spec item.
+			Register(Wrap(basic.NewTerraformRequiredProvidersDeclarationRule(), WithLink(tfnfr26Link), WithSeverity(tflint.ERROR)), noSpecMetadata("0.1.0")),
+			Register(Wrap(basic.NewTerraformRequiredVersionDeclarationRule(), WithLink(tfnfr25Link), WithSeverity(tflint.ERROR)), coreMetadata("TFNFR25", "0.1.0")),
+			Register(Wrap(basic.NewTerraformSensitiveVariableNoDefaultRule()), noSpecMetadata("0.1.0")),
+			Register(Wrap(basic.NewTerraformVariableNullableFalseRule(), WithLink(tfnfr21Link), WithSeverity(tflint.ERROR)), coreMetadata("TFNFR21", "0.1.0")),
+			Register(Wrap(basic.NewTerraformVariableSeparateRule()), noSpecMetadata("0.1.0")),
+			Register(Wrap(azurerm.NewAzurermResourceTagRule()), noSpecMetadata("0.1.0")),
+			Register(NewTerraformDotTfRule(), coreMetadata("TFNFR26", "0.1.0")),
+			Register(NewModuleSourceRule(), coreMetadata("TFFR1", "0.1.0")),
+			Register(NewProviderVersionRule("modtm", "Azure/modtm", "~> 0.3", []string{
 				"0.2.999",
 				"1.0.0",
-			}),
+			}), coreMetadata("TFFR3", "0.1.0")),
 		},
-		waf.GetRules(),
-		interfaces.Rules,
-		outputs.Rules,
-	)
+	},
+	{
+		Name:  CategoryWaf,
+		Rules: registerWaf(waf.RuleSpecs()),
+	},
+	{
+		Name:  CategoryInterfaces,
+		Rules: registerInterfaces(interfaces.Rules),
+	},
+	{
+		Name:  CategoryOutputs,
+		Rules: registerAll(CategoryOutputs, Metadata{SpecID: "RMFR7", Level: LevelMust, Since: "0.1.0"}, outputs.Rules...),
+	},
+}
+
+// coreMetadata returns the metadata of a MUST level rule in the rules category, introduced in the given plugin release.
+func coreMetadata(specID, since string) Metadata {
+	return Metadata{
+		SpecID:   specID,
+		Level:    LevelMust,
+		Category: CategoryRules,
+		Since:    since,
+	}
+}
+
+// noSpecMetadata returns the metadata of a rule in the rules category that enforces no spec item, introduced in the given plugin release.
+func noSpecMetadata(since string) Metadata {
+	return Metadata{
+		Level:    LevelNone,
+		Category: CategoryRules,
+		Since:    since,
+	}
+}
+
+// wafMetadata returns the metadata of a WAF rule, introduced in the given plugin release.
+func wafMetadata(since string) Metadata {
+	return Metadata{
+		SpecID:   "SFR2",
+		Level:    LevelShould,
+		Category: CategoryWaf,
+		Since:    since,
+	}
+}
+
+// registerWaf registers the WAF rules declared by the specs, each with the plugin release given by its spec.
+func registerWaf(specs []waf.RuleSpec) []tflint.Rule {
+	registered := make([]tflint.Rule, 0, len(specs))
+	for _, s := range specs {
+		// The specs were validated when they were loaded.
+		rule, _ := s.NewRule()
+		registered = append(registered, Register(rule, wafMetadata(s.Since)))
+	}
+	return registered
+}
+
+// interfaceSpecIDs maps the interface rules onto the spec item defining their schema.
+// The location, lock and tags rules are left out: they enforce no single spec item.
+var interfaceSpecIDs = map[string]string{
+	"customer_managed_key": "RMFR5",
+	"diagnostic_settings":  "RMFR5",
+	"managed_identities":   "RMFR5",
+	"private_endpoints":    "RMFR5",
+	"role_assignments":     "RMFR5",
+}
+
+// registerInterfaces registers the interface rules, each with the spec item it enforces, see interfaceSpecIDs.
+func registerInterfaces(rules []tflint.Rule) []tflint.Rule {
+	registered := make([]tflint.Rule, 0, len(rules))
+	for _, rule := range rules {
+		md := Metadata{Level: LevelNone, Category: CategoryInterfaces, Since: "0.1.0"}
+		if specID, ok := interfaceSpecIDs[rule.Name()]; ok {
+			md.SpecID = specID
+			md.Level = LevelMust
+		}
+		registered = append(registered, Register(rule, md))
+	}
+	return registered
+}
+
+// Rules is the flattened list of all the rules in Categories.
+var Rules = func() []tflint.Rule {
+	var rules []tflint.Rule
+	for _, c := range Categories {
+		rules = append(rules, c.Rules...)
+	}
+	return rules
 }()
 
+var _ tflint.Rule = new(wrappedRule)
+
+// wrappedRule adapts a rule from another ruleset to AVM.
+// Unless the options say otherwise it keeps the name, link and severity of the wrapped rule, and is disabled by default.
 type wrappedRule struct {
 	tflint.Rule
+	namePrefix string
+	link       string
+	severity   *tflint.Severity
+	enabled    bool
+}
+
+// WrapOption customises a wrapped rule.
+type WrapOption func(*wrappedRule)
+
+// WithNamePrefix prefixes the name of the wrapped rule, e.g. with an AVM-specific prefix to set it apart from the same rule in its original ruleset.
+// It is opt-in: the builtin wrapped rules keep their names, which users already configure in rule blocks.
+func WithNamePrefix(prefix string) WrapOption {
+	return func(w *wrappedRule) {
+		w.namePrefix = prefix
+	}
+}
+
+// WithLink replaces the link of the wrapped rule, e.g. with a link to the AVM spec.
+func WithLink(link string) WrapOption {
+	return func(w *wrappedRule) {
+		w.link = link
+	}
 }
 
-func (*wrappedRule) Enabled() bool {
-	return false
+// WithSeverity replaces the severity of the wrapped rule.
+func WithSeverity(severity tflint.Severity) WrapOption {
+	return func(w *wrappedRule) {
+		w.severity = &severity
+	}
 }
 
-func Wrap(r tflint.Rule) tflint.Rule {
-	return &wrappedRule{
+// WithEnabled sets whether the wrapped rule is enabled by default.
+func WithEnabled(enabled bool) WrapOption {
+	return func(w *wrappedRule) {
+		w.enabled = enabled
+	}
+}
+
+// Wrap adapts a rule from another ruleset to AVM, see the WrapOption functions.
+func Wrap(r tflint.Rule, opts ...WrapOption) tflint.Rule {
+	w := &wrappedRule{
 		Rule: r,
 	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+func (w *wrappedRule) Name() string {
+	return w.namePrefix + w.Rule.Name()
+}
+
+func (w *wrappedRule) Link() string {
+	if w.link != "" {
+		return w.link
+	}
+	return w.Rule.Link()
+}
+
+func (w *wrappedRule) Severity() tflint.Severity {
+	if w.severity != nil {
+		return *w.severity
+	}
+	return w.Rule.Severity()
+}
+
+func (w *wrappedRule) Enabled() bool {
+	return w.enabled
+}
+
+func (w *wrappedRule) Unwrap() tflint.Rule {
+	return w.Rule
+}
+
+// Check runs the wrapped rule and reports its issues under the name, link and severity of the wrapper.
+func (w *wrappedRule) Check(runner tflint.Runner) error {
+	return w.Rule.Check(&issueRunner{
+		Runner: runner,
+		rule:   w,
+	})
 }
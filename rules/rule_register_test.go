@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestDuplicateRuleNames(t *testing.T) {
@@ -18,3 +22,59 @@ func TestDuplicateRuleNames(t *testing.T) {
 		names[name] = true
 	}
 }
+
+func TestWrap(t *testing.T) {
+	cases := []struct {
+		desc     string
+		opts     []rules.WrapOption
+		name     string
+		link     string
+		severity tflint.Severity
+		enabled  bool
+	}{
+		{
+			desc:     "defaults",
+			name:     "wrapped",
+			severity: tflint.ERROR,
+			enabled:  false,
+		},
+		{
+			desc:     "name prefix",
+			opts:     []rules.WrapOption{rules.WithNamePrefix("avm_")},
+			name:     "avm_wrapped",
+			severity: tflint.ERROR,
+			enabled:  false,
+		},
+		{
+			desc: "all overrides",
+			opts: []rules.WrapOption{
+				rules.WithNamePrefix("avm_"),
+				rules.WithLink("https://azure.github.io/Azure-Verified-Modules/specs/terraform/"),
+				rules.WithSeverity(tflint.WARNING),
+				rules.WithEnabled(true),
+			},
+			name:     "avm_wrapped",
+			link:     "https://azure.github.io/Azure-Verified-Modules/specs/terraform/",
+			severity: tflint.WARNING,
+			enabled:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			rule := rules.Wrap(&issueRule{name: "wrapped"}, tc.opts...)
+			assert.Equal(t, tc.name, rule.Name())
+			assert.Equal(t, tc.link, rule.Link())
+			assert.Equal(t, tc.severity, rule.Severity())
+			assert.Equal(t, tc.enabled, rule.Enabled())
+
+			runner := helper.TestRunner(t, map[string]string{"main.tf": ""})
+			require.NoError(t, rule.Check(runner))
+			require.Len(t, runner.Issues, 1)
+			assert.Equal(t, rule, runner.Issues[0].Rule)
+			assert.Equal(t, "something is wrong", runner.Issues[0].Message)
+		})
+	}
+}
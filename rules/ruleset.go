@@ -0,0 +1,249 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/Azure/tflint-ruleset-avm/common"
+	"github.com/Azure/tflint-ruleset-avm/interfaces"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.RuleSet = new(RuleSet)
+
+// Config is the plugin configuration declared in the `plugin "avm"` block.
+type Config struct {
+	Waf        *bool `hclext:"waf,optional"`
+	Interfaces *bool `hclext:"interfaces,optional"`
+	Outputs    *bool `hclext:"outputs,optional"`
+
+	// ModuleClass overrides the module class detected from the directory or repository name.
+	ModuleClass string `hclext:"module_class,optional"`
+
+	// SpecVersion pins the interface definitions to the given spec version, see interfaces.SpecVersions.
+	SpecVersion string `hclext:"spec_version,optional"`
+
+	ProviderVersions []ProviderVersionConfig `hclext:"provider_version,block"`
+	CustomRules      []CustomRuleConfig      `hclext:"custom_rule,block"`
+}
+
+// categoryEnabled returns the toggle for the given category, or nil if it is not set.
+func (c *Config) categoryEnabled(category string) *bool {
+	switch category {
+	case CategoryWaf:
+		return c.Waf
+	case CategoryInterfaces:
+		return c.Interfaces
+	case CategoryOutputs:
+		return c.Outputs
+	}
+	return nil
+}
+
+// RuleSet is the AVM ruleset.
+// It extends the builtin ruleset with a plugin config that can turn whole rule categories on or off,
+// and skips the rules that do not apply to the class of the module being linted.
+type RuleSet struct {
+	tflint.BuiltinRuleSet
+	config       *Config
+	globalConfig *tflint.Config
+	moduleClass  common.ModuleClass
+}
+
+// NewRuleSet returns a new ruleset containing all the rules in Categories.
+func NewRuleSet(name, version string) *RuleSet {
+	return &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Name:    name,
+			Version: version,
+			Rules:   slices.Clone(Rules),
+		},
+		config: &Config{},
+	}
+}
+
+// CategoryOf returns the category of the named rule, or an empty string if the rule is unknown.
+func (r *RuleSet) CategoryOf(ruleName string) string {
+	md, _ := MetadataOf(ruleName)
+	return md.Category
+}
+
+// ApplyGlobalConfig keeps hold of the global config so that ApplyConfig can take the rule blocks into account.
+func (r *RuleSet) ApplyGlobalConfig(config *tflint.Config) error {
+	r.globalConfig = config
+	return r.BuiltinRuleSet.ApplyGlobalConfig(config)
+}
+
+// ConfigSchema returns the schema of the `plugin "avm"` block.
+func (r *RuleSet) ConfigSchema() *hclext.BodySchema {
+	return hclext.ImpliedBodySchema(r.config)
+}
+
+// ApplyConfig decodes the plugin config, adds the rules declared in it and recalculates the enabled rules.
+func (r *RuleSet) ApplyConfig(body *hclext.BodyContent) error {
+	if diags := hclext.DecodeBody(body, nil, r.config); diags.HasErrors() {
+		return diags
+	}
+
+	if err := r.applyModuleClass(); err != nil {
+		return err
+	}
+	if err := r.applySpecVersion(); err != nil {
+		return err
+	}
+
+	for _, p := range r.config.ProviderVersions {
+		if p.Source == "" {
+			return fmt.Errorf("provider_version %q: source must be set", p.Name)
+		}
+		rule := NewProviderVersionRule(p.Name, p.Source, p.Recommended, p.VersionsShouldFail)
+		if slices.Contains(r.RuleNames(), rule.Name()) {
+			return fmt.Errorf("provider_version %q: rule %s already exists, use a rule block to configure it", p.Name, rule.Name())
+		}
+		r.Rules = append(r.Rules, Register(rule, Metadata{SpecID: "TFFR3", Level: LevelMust, Category: CategoryRules}))
+	}
+
+	for _, c := range r.config.CustomRules {
+		if slices.Contains(r.RuleNames(), c.Name) {
+			return fmt.Errorf("custom_rule %q: rule %s already exists", c.Name, c.Name)
+		}
+		rule, err := NewCustomRule(c)
+		if err != nil {
+			return err
+		}
+		r.Rules = append(r.Rules, Register(rule, Metadata{Category: CategoryCustom}))
+	}
+
+	r.EnabledRules = []tflint.Rule{}
+	for _, rule := range r.Rules {
+		if r.ruleEnabled(rule) {
+			r.EnabledRules = append(r.EnabledRules, rule)
+		}
+	}
+	return nil
+}
+
+// ModuleClass returns the module class set in the plugin config, or an empty string if it is detected when the module is checked, see NewRunner.
+func (r *RuleSet) ModuleClass() common.ModuleClass {
+	return r.moduleClass
+}
+
+// applyModuleClass sets the module class from the plugin config.
+func (r *RuleSet) applyModuleClass() error {
+	if r.config.ModuleClass == "" {
+		return nil
+	}
+	class, err := common.ParseModuleClass(r.config.ModuleClass)
+	if err != nil {
+		return fmt.Errorf("module_class: %w", err)
+	}
+	r.moduleClass = class
+	return nil
+}
+
+// NewRunner detects the class of the module being checked when it is not set in the plugin config,
+// so that the rules that do not apply to it are skipped.
+// The module directory is only known at this point: with `--chdir` or `--recursive`, it is not the working directory of the plugin.
+func (r *RuleSet) NewRunner(runner tflint.Runner) (tflint.Runner, error) {
+	if r.moduleClass != "" {
+		return runner, nil
+	}
+	dir, err := moduleDir(runner)
+	if err != nil {
+		return nil, err
+	}
+	class, ok := common.DetectModuleClass(dir)
+	if !ok {
+		return runner, nil
+	}
+	return &moduleClassRunner{
+		Runner: runner,
+		class:  class,
+	}, nil
+}
+
+// moduleDir returns the directory of the module being checked.
+// The file names of the runner are relative to the original working directory of tflint.
+func moduleDir(runner tflint.Runner) (string, error) {
+	wd, err := runner.GetOriginalwd()
+	if err != nil {
+		return "", err
+	}
+	files, err := runner.GetFiles()
+	if err != nil {
+		return "", err
+	}
+	for name := range files {
+		if filepath.IsAbs(name) {
+			return filepath.Dir(name), nil
+		}
+		return filepath.Join(wd, filepath.Dir(name)), nil
+	}
+	return wd, nil
+}
+
+var _ tflint.Runner = new(moduleClassRunner)
+
+// moduleClassRunner carries the module class detected when the module is checked, see registeredRule.Check.
+type moduleClassRunner struct {
+	tflint.Runner
+	class common.ModuleClass
+}
+
+// applySpecVersion replaces the interface rules with ones enforcing the spec version set in the plugin config.
+func (r *RuleSet) applySpecVersion() error {
+	if r.config.SpecVersion == "" {
+		return nil
+	}
+	versioned, err := interfaces.NewRules(r.config.SpecVersion)
+	if err != nil {
+		return fmt.Errorf("spec_version: %w", err)
+	}
+	byName := make(map[string]tflint.Rule, len(versioned))
+	for _, rule := range versioned {
+		byName[rule.Name()] = rule
+	}
+	for i, rule := range r.Rules {
+		if v, ok := byName[rule.Name()]; ok && r.CategoryOf(rule.Name()) == CategoryInterfaces {
+			md, _ := MetadataOf(rule.Name())
+			r.Rules[i] = Register(v, md)
+		}
+	}
+	return nil
+}
+
+// ruleEnabled works out whether a rule should run.
+// Rules that do not apply to the module class are always skipped.
+// Otherwise the priority is as follows:
+//
+// 1. --only option
+// 2. Rule config declared in each "rule" block
+// 3. Category toggle declared in the "plugin" block
+// 4. The `disabled_by_default` declared in global "config" block
+// 5. The default of the rule itself
+func (r *RuleSet) ruleEnabled(rule tflint.Rule) bool {
+	global := r.globalConfig
+	if global == nil {
+		global = &tflint.Config{}
+	}
+
+	if r.moduleClass != "" && !common.AppliesToModuleClass(rule, r.moduleClass) {
+		return false
+	}
+	if len(global.Only) > 0 {
+		return slices.Contains(global.Only, rule.Name())
+	}
+	if cfg := global.Rules[rule.Name()]; cfg != nil {
+		return cfg.Enabled
+	}
+	if enabled := r.config.categoryEnabled(r.CategoryOf(rule.Name())); enabled != nil {
+		return *enabled
+	}
+	if global.DisabledByDefault {
+		return false
+	}
+	return rule.Enabled()
+}
@@ -0,0 +1,333 @@
+package rules_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Azure/tflint-ruleset-avm/rules"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// applyRuleSetConfig applies the global config and the given plugin config body to a new ruleset.
+func applyRuleSetConfig(t *testing.T, global *tflint.Config, pluginConfig string) (*rules.RuleSet, error) {
+	rs := rules.NewRuleSet("avm", "test")
+	require.NoError(t, rs.ApplyGlobalConfig(global))
+	file, diags := hclsyntax.ParseConfig([]byte(pluginConfig), "plugin.hcl", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+	content, diags := hclext.Content(file.Body, rs.ConfigSchema())
+	require.False(t, diags.HasErrors(), diags.Error())
+	return rs, rs.ApplyConfig(content)
+}
+
+func enabledRuleNames(rs *rules.RuleSet) map[string]bool {
+	names := make(map[string]bool)
+	for _, rule := range rs.EnabledRules {
+		names[rule.Name()] = true
+	}
+	return names
+}
+
+func categoryRules(category string) []tflint.Rule {
+	for _, c := range rules.Categories {
+		if c.Name == category {
+			return c.Rules
+		}
+	}
+	return nil
+}
+
+func TestRuleSetCategoryToggles(t *testing.T) {
+	wafRule := categoryRules(rules.CategoryWaf)[0].Name()
+	interfaceRule := categoryRules(rules.CategoryInterfaces)[0].Name()
+	outputRule := categoryRules(rules.CategoryOutputs)[0].Name()
+	coreRule := rules.NewModuleSourceRule().Name()
+
+	cases := []struct {
+		desc     string
+		global   *tflint.Config
+		config   string
+		enabled  []string
+		disabled []string
+	}{
+		{
+			desc:    "no plugin config keeps rule defaults",
+			global:  &tflint.Config{},
+			config:  ``,
+			enabled: []string{wafRule, interfaceRule, outputRule, coreRule},
+		},
+		{
+			desc:     "waf disabled",
+			global:   &tflint.Config{},
+			config:   `waf = false`,
+			enabled:  []string{interfaceRule, outputRule, coreRule},
+			disabled: []string{wafRule},
+		},
+		{
+			desc:     "interfaces and outputs disabled",
+			global:   &tflint.Config{},
+			config:   "interfaces = false\noutputs = false",
+			enabled:  []string{wafRule, coreRule},
+			disabled: []string{interfaceRule, outputRule},
+		},
+		{
+			desc:     "category enabled overrides disabled_by_default",
+			global:   &tflint.Config{DisabledByDefault: true},
+			config:   `waf = true`,
+			enabled:  []string{wafRule},
+			disabled: []string{interfaceRule, outputRule, coreRule},
+		},
+		{
+			desc: "rule block overrides category toggle",
+			global: &tflint.Config{Rules: map[string]*tflint.RuleConfig{
+				wafRule: {Name: wafRule, Enabled: true},
+			}},
+			config:   `waf = false`,
+			enabled:  []string{wafRule},
+			disabled: []string{categoryRules(rules.CategoryWaf)[1].Name()},
+		},
+		{
+			desc:     "only overrides category toggle",
+			global:   &tflint.Config{Only: []string{wafRule}},
+			config:   `waf = false`,
+			enabled:  []string{wafRule},
+			disabled: []string{interfaceRule, outputRule, coreRule},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			rs, err := applyRuleSetConfig(t, tc.global, tc.config)
+			require.NoError(t, err)
+			enabled := enabledRuleNames(rs)
+			for _, name := range tc.enabled {
+				assert.Truef(t, enabled[name], "rule %s should be enabled", name)
+			}
+			for _, name := range tc.disabled {
+				assert.Falsef(t, enabled[name], "rule %s should be disabled", name)
+			}
+		})
+	}
+}
+
+func TestRuleSetCategoryOf(t *testing.T) {
+	rs := rules.NewRuleSet("avm", "test")
+	for _, c := range rules.Categories {
+		for _, rule := range c.Rules {
+			assert.Equal(t, c.Name, rs.CategoryOf(rule.Name()))
+		}
+	}
+	assert.Len(t, rs.Rules, len(rules.Rules))
+}
+
+func TestRuleSetProviderVersionBlocks(t *testing.T) {
+	rs, err := applyRuleSetConfig(t, &tflint.Config{}, `
+provider_version "azurerm" {
+  source               = "hashicorp/azurerm"
+  recommended          = "~> 3.71"
+  versions_should_fail = ["3.70.0", "4.0.0"]
+}
+
+provider_version "random" {
+  source = "hashicorp/random"
+}`)
+	require.NoError(t, err)
+	enabled := enabledRuleNames(rs)
+	assert.True(t, enabled["provider_azurerm_version"])
+	assert.True(t, enabled["provider_random_version"])
+	assert.True(t, enabled["provider_modtm_version"])
+	assert.Equal(t, rules.CategoryRules, rs.CategoryOf("provider_azurerm_version"))
+	assert.Contains(t, rs.RuleNames(), "provider_azurerm_version")
+}
+
+func TestRuleSetProviderVersionBlockErrors(t *testing.T) {
+	cases := []struct {
+		desc   string
+		config string
+	}{
+		{
+			desc:   "missing source",
+			config: `provider_version "azurerm" {}`,
+		},
+		{
+			desc: "duplicate of a builtin rule",
+			config: `provider_version "modtm" {
+  source = "Azure/modtm"
+}`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			_, err := applyRuleSetConfig(t, &tflint.Config{}, tc.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRuleSetModuleClass(t *testing.T) {
+	const requiredOutput = "required_output_rmfr7"
+	cases := []struct {
+		desc     string
+		global   *tflint.Config
+		config   string
+		enabled  []string
+		disabled []string
+	}{
+		{
+			desc:     "pattern module skips resource only rules",
+			global:   &tflint.Config{},
+			config:   `module_class = "pattern"`,
+			enabled:  []string{"role_assignments", "tags"},
+			disabled: []string{requiredOutput, "private_endpoints", "customer_managed_key"},
+		},
+		{
+			desc:    "resource module runs resource only rules",
+			global:  &tflint.Config{},
+			config:  `module_class = "res"`,
+			enabled: []string{requiredOutput, "private_endpoints", "role_assignments"},
+		},
+		{
+			desc:     "utility module skips interfaces",
+			global:   &tflint.Config{},
+			config:   `module_class = "utility"`,
+			enabled:  []string{"required_module_source_tffr1"},
+			disabled: []string{requiredOutput, "role_assignments", "tags"},
+		},
+		{
+			desc: "rule block does not bring back a rule that does not apply",
+			global: &tflint.Config{Rules: map[string]*tflint.RuleConfig{
+				requiredOutput: {Name: requiredOutput, Enabled: true},
+			}},
+			config:   `module_class = "ptn"`,
+			disabled: []string{requiredOutput},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			rs, err := applyRuleSetConfig(t, tc.global, tc.config)
+			require.NoError(t, err)
+			enabled := enabledRuleNames(rs)
+			for _, name := range tc.enabled {
+				assert.Truef(t, enabled[name], "rule %s should be enabled", name)
+			}
+			for _, name := range tc.disabled {
+				assert.Falsef(t, enabled[name], "rule %s should be disabled", name)
+			}
+		})
+	}
+}
+
+func TestRuleSetDetectedModuleClass(t *testing.T) {
+	const requiredOutput = "required_output_rmfr7"
+	cases := []struct {
+		desc   string
+		file   string
+		issues bool
+	}{
+		{
+			desc:   "resource module directory",
+			file:   "avm-res-network-foo/outputs.tf",
+			issues: true,
+		},
+		{
+			desc: "pattern module directory",
+			file: "avm-ptn-foo/outputs.tf",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			rs, err := applyRuleSetConfig(t, &tflint.Config{Only: []string{requiredOutput}}, ``)
+			require.NoError(t, err)
+			require.Len(t, rs.EnabledRules, 1)
+
+			runner := helper.TestRunner(t, map[string]string{tc.file: ``})
+			checked, err := rs.NewRunner(runner)
+			require.NoError(t, err)
+			require.NoError(t, rs.EnabledRules[0].Check(checked))
+			assert.Equal(t, tc.issues, len(runner.Issues) > 0, runner.Issues)
+		})
+	}
+}
+
+func TestRuleSetInvalidModuleClass(t *testing.T) {
+	_, err := applyRuleSetConfig(t, &tflint.Config{}, `module_class = "module"`)
+	assert.Error(t, err)
+}
+
+func TestRuleSetSpecVersion(t *testing.T) {
+	initial := `variable "role_assignments" {
+  type = map(object({
+    role_definition_id_or_name             = string
+    principal_id                           = string
+    description                            = optional(string, null)
+    skip_service_principal_aad_check       = optional(bool, false)
+    condition                              = optional(string, null)
+    condition_version                      = optional(string, null)
+    delegated_managed_identity_resource_id = optional(string, null)
+  }))
+  default  = {}
+  nullable = false
+}`
+	cases := []struct {
+		desc     string
+		config   string
+		severity []tflint.Severity
+	}{
+		{
+			desc:     "latest spec version reports the deprecated definition as a warning",
+			config:   ``,
+			severity: []tflint.Severity{tflint.WARNING},
+		},
+		{
+			desc:   "pinned spec version accepts the definition",
+			config: `spec_version = "1"`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			rs, err := applyRuleSetConfig(t, &tflint.Config{}, tc.config)
+			require.NoError(t, err)
+			var rule tflint.Rule
+			for _, r := range rs.Rules {
+				if r.Name() == "role_assignments" {
+					rule = r
+				}
+			}
+			require.NotNil(t, rule)
+
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": initial})
+			require.NoError(t, rule.Check(runner))
+
+			var severities []tflint.Severity
+			for _, issue := range runner.Issues {
+				assert.Equal(t, "role_assignments", issue.Rule.Name())
+				assert.True(t, strings.HasPrefix(issue.Message, "RMFR5: "), issue.Message)
+				severities = append(severities, issue.Rule.Severity())
+			}
+			assert.Equal(t, tc.severity, severities)
+		})
+	}
+}
+
+func TestRuleSetInvalidSpecVersion(t *testing.T) {
+	_, err := applyRuleSetConfig(t, &tflint.Config{}, `spec_version = "latest"`)
+	assert.Error(t, err)
+}
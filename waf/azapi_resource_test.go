@@ -0,0 +1,100 @@
+package waf_test
+
+import (
+	"testing"
+
+	"github.com/prashantv/gostub"
+
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func TestAzapiResource(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     tflint.Rule
+		content  string
+		expected helper.Issues
+	}{
+		{
+			name: "load balancer sku",
+			rule: ruleByName(t, "waf_use_standard_load_balancer_sku_azapi_sku_name"),
+			content: `
+	resource "azapi_resource" "example" {
+		type = "Microsoft.Network/loadBalancers@2023-09-01"
+		body = {
+			sku = { name = "Basic" }
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    ruleByName(t, "waf_use_standard_load_balancer_sku_azapi_sku_name"),
+					Message: "Basic is an invalid attribute value of `body.sku.name` - expecting (one of) [Standard]",
+				},
+			},
+		},
+		{
+			name: "storage account sku in a JSON body",
+			rule: ruleByName(t, "waf_st_1_azapi_sku_name"),
+			content: `
+	resource "azapi_resource" "example" {
+		type = "Microsoft.Storage/storageAccounts@2023-01-01"
+		body = jsonencode({
+			kind = "StorageV2"
+			sku  = { name = "Standard_LRS" }
+		})
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    ruleByName(t, "waf_st_1_azapi_sku_name"),
+					Message: "Standard_LRS is an invalid attribute value of `body.sku.name` - expecting (one of) [Standard_GRS Standard_ZRS Premium_ZRS]",
+				},
+			},
+		},
+		{
+			name: "app service plan zone redundancy",
+			rule: ruleByName(t, "waf_asp_1_azapi_zone_redundant"),
+			content: `
+	resource "azapi_resource" "example" {
+		type = "Microsoft.Web/serverfarms@2022-09-01"
+		body = {
+			sku        = { name = "P1v3" }
+			properties = { zoneRedundant = false }
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    ruleByName(t, "waf_asp_1_azapi_zone_redundant"),
+					Message: "false is an invalid attribute value of `body.properties.zoneRedundant` - expecting (one of) [true]",
+				},
+			},
+		},
+		{
+			name: "azurerm rule ignoring azapi resources",
+			rule: ruleByName(t, "waf_use_standard_load_balancer_sku_sku"),
+			content: `
+	resource "azapi_resource" "example" {
+		type = "Microsoft.Network/loadBalancers@2023-09-01"
+		body = {
+			sku = { name = "Basic" }
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+	}
+
+	filename := "main.tf"
+	for _, c := range testCases {
+		tc := c
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{filename: tc.content})
+			stub := gostub.Stub(&attrvalue.AppFs, mockFs(tc.content))
+			defer stub.Reset()
+			if err := tc.rule.Check(runner); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			helper.AssertIssuesWithoutRange(t, tc.expected, runner.Issues)
+		})
+	}
+}
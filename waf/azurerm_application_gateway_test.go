@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermApplicationGatewayZones(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,7 +19,7 @@ func TestAzurermApplicationGatewayZones(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermApplicationGatewayZones(),
+			rule: ruleByName(t, "waf_agw_1_zones"),
 			content: `
 	variable "zones" {
 		type    = list(number)
@@ -35,7 +32,7 @@ func TestAzurermApplicationGatewayZones(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermApplicationGatewayZones(),
+			rule: ruleByName(t, "waf_agw_1_zones"),
 			content: `
 	variable "zones" {
 		type    = list(number)
@@ -46,14 +43,14 @@ func TestAzurermApplicationGatewayZones(t *testing.T) {
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermApplicationGatewayZones(),
-					Message: "\"[2 3]\" is an invalid attribute value of `zones` - expecting (one of) [[1 2 3]]",
+					Rule:    ruleByName(t, "waf_agw_1_zones"),
+					Message: "`var.zones (default) -> azurerm_application_gateway.example.zones`: \"[2 3]\" is an invalid attribute value of `zones` - expecting (one of) [[1 2 3]]",
 				},
 			},
 		},
 		{
 			name: "correct with string list",
-			rule: wafRules.AzurermApplicationGatewayZones(),
+			rule: ruleByName(t, "waf_agw_1_zones"),
 			content: `
 	variable "zones" {
 		type    = list(string)
@@ -66,7 +63,7 @@ func TestAzurermApplicationGatewayZones(t *testing.T) {
 		},
 		{
 			name: "correct but different order",
-			rule: wafRules.AzurermApplicationGatewayZones(),
+			rule: ruleByName(t, "waf_agw_1_zones"),
 			content: `
 	variable "zones" {
 		type    = list(number)
@@ -79,7 +76,7 @@ func TestAzurermApplicationGatewayZones(t *testing.T) {
 		},
 		{
 			name: "variable without default",
-			rule: wafRules.AzurermApplicationGatewayZones(),
+			rule: ruleByName(t, "waf_agw_1_zones"),
 			content: `
 		variable "zones" {
 			type    = list(number)
@@ -106,8 +103,6 @@ func TestAzurermApplicationGatewayZones(t *testing.T) {
 	}
 }
 func TestAzurermApplicationGatewaySku(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -116,7 +111,7 @@ func TestAzurermApplicationGatewaySku(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermApplicationGatewaySku(),
+			rule: ruleByName(t, "waf_agw_4_sku_name"),
 			content: `
 	variable "sku" {
 		type = list(string)
@@ -132,7 +127,7 @@ func TestAzurermApplicationGatewaySku(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermApplicationGatewaySku(),
+			rule: ruleByName(t, "waf_agw_4_sku_name"),
 			content: `
 	variable "sku" {
 		type = string
@@ -145,14 +140,14 @@ func TestAzurermApplicationGatewaySku(t *testing.T) {
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermApplicationGatewaySku(),
-					Message: "Standard_v3 is an invalid attribute value of `name` - expecting (one of) [Standard_v2 WAF_v2]",
+					Rule:    ruleByName(t, "waf_agw_4_sku_name"),
+					Message: "`var.sku (default) -> azurerm_application_gateway.example.sku.name`: Standard_v3 is an invalid attribute value of `name` - expecting (one of) [Standard_v2 WAF_v2]",
 				},
 			},
 		},
 		{
 			name: "null value",
-			rule: wafRules.AzurermApplicationGatewaySku(),
+			rule: ruleByName(t, "waf_agw_4_sku_name"),
 			content: `
 	variable "sku" {
 		type    = string
@@ -165,7 +160,7 @@ func TestAzurermApplicationGatewaySku(t *testing.T) {
 		},
 		{
 			name: "missing attribute",
-			rule: wafRules.AzurermApplicationGatewaySku(),
+			rule: ruleByName(t, "waf_agw_4_sku_name"),
 			content: `
 	resource "azurerm_application_gateway" "example" {
 
@@ -1,17 +0,0 @@
-package waf
-
-import (
-	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-)
-
-func (wf WafRules) AzurermCosmosDbAccountBackupMode() *attrvalue.SimpleRule[string] {
-	return attrvalue.NewSimpleNestedBlockRule[string](
-		"azurerm_cosmosdb_account",
-		"backup",
-		"type",
-		[]string{"Continuous"},
-		"https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/DocumentDB/databaseAccounts/#configure-continuous-backup-mode",
-		true,
-		"",
-	)
-}
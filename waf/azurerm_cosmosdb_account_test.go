@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermCosmosDbAccountBackupMode(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,7 +19,7 @@ func TestAzurermCosmosDbAccountBackupMode(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermCosmosDbAccountBackupMode(),
+			rule: ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
 			content: `
 	variable "backup_type" {
 		type    = string
@@ -37,7 +34,7 @@ func TestAzurermCosmosDbAccountBackupMode(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermCosmosDbAccountBackupMode(),
+			rule: ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
 			content: `
     variable "backup_type" {
 		type    = string
@@ -50,28 +47,28 @@ func TestAzurermCosmosDbAccountBackupMode(t *testing.T) {
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermCosmosDbAccountBackupMode(),
-					Message: "Periodic is an invalid attribute value of `type` - expecting (one of) [Continuous]",
+					Rule:    ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
+					Message: "`var.backup_type (default) -> azurerm_cosmosdb_account.example.backup.type`: Periodic is an invalid attribute value of `type` - expecting (one of) [Continuous]",
 				},
 			},
 		},
 		{
 			name: "missing block",
-			rule: wafRules.AzurermCosmosDbAccountBackupMode(),
+			rule: ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
 			content: `
 	resource "azurerm_cosmosdb_account" "example" {
 
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermCosmosDbAccountBackupMode(),
+					Rule:    ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
 					Message: "The attribute `type` must be specified",
 				},
 			},
 		},
 		{
 			name: "missing block attribute",
-			rule: wafRules.AzurermCosmosDbAccountBackupMode(),
+			rule: ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
 			content: `
 	resource "azurerm_cosmosdb_account" "example" {
 		backup {
@@ -80,14 +77,60 @@ func TestAzurermCosmosDbAccountBackupMode(t *testing.T) {
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermCosmosDbAccountBackupMode(),
+					Rule:    ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
 					Message: "The attribute `type` must be specified",
 				},
 			},
 		},
+		{
+			name: "dynamic block with unknown backup",
+			rule: ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
+			content: `
+	variable "backup" {
+		type = object({
+			type                = string
+			interval_in_minutes = optional(number)
+		})
+	}
+	resource "azurerm_cosmosdb_account" "example" {
+		dynamic "backup" {
+			for_each = [var.backup]
+			content {
+				type                = backup.value.type
+				interval_in_minutes = backup.value.interval_in_minutes
+			}
+		}
+	}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "dynamic block with periodic backup",
+			rule: ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
+			content: `
+	variable "backup" {
+		type = object({
+			type = optional(string, "Periodic")
+		})
+		default = {}
+	}
+	resource "azurerm_cosmosdb_account" "example" {
+		dynamic "backup" {
+			for_each = [var.backup]
+			content {
+				type = backup.value.type
+			}
+		}
+	}`,
+			expected: helper.Issues{
+				{
+					Rule:    ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
+					Message: "Periodic is an invalid attribute value of `type` - expecting (one of) [Continuous]",
+				},
+			},
+		},
 		{
 			name: "missing resource",
-			rule: wafRules.AzurermCosmosDbAccountBackupMode(),
+			rule: ruleByName(t, "waf_configure_continuous_backup_mode_backup_type"),
 			content: `
 	resource "something_else" "example" {
 
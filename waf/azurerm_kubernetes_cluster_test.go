@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermKubernetesClusterZones(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,7 +19,7 @@ func TestAzurermKubernetesClusterZones(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermKubernetesClusterZones(),
+			rule: ruleByName(t, "waf_aks_1_zones"),
 			content: `
 	resource "azurerm_kubernetes_cluster" "example" {
 		zones = [1, 2, 3]
@@ -31,14 +28,14 @@ func TestAzurermKubernetesClusterZones(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermKubernetesClusterZones(),
+			rule: ruleByName(t, "waf_aks_1_zones"),
 			content: `
 	resource "azurerm_kubernetes_cluster" "example" {
 		zones = [1, 2]
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermKubernetesClusterZones(),
+					Rule:    ruleByName(t, "waf_aks_1_zones"),
 					Message: "\"[1 2]\" is an invalid attribute value of `zones` - expecting (one of) [[1 2 3]]",
 				},
 			},
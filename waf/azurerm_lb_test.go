@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermLbSku(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,7 +19,7 @@ func TestAzurermLbSku(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermLbSku(),
+			rule: ruleByName(t, "waf_use_standard_load_balancer_sku_sku"),
 			content: `
 	resource "azurerm_lb" "example" {
 		sku = "Standard"
@@ -31,14 +28,14 @@ func TestAzurermLbSku(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermLbSku(),
+			rule: ruleByName(t, "waf_use_standard_load_balancer_sku_sku"),
 			content: `
 	resource "azurerm_lb" "example" {
 		sku = "Basic"
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermLbSku(),
+					Rule:    ruleByName(t, "waf_use_standard_load_balancer_sku_sku"),
 					Message: "Basic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
 				},
 			},
@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermMySqlFlexibleServerZoneRedundancy(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,7 +19,7 @@ func TestAzurermMySqlFlexibleServerZoneRedundancy(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermMySqlFlexibleServerZoneRedundancy(),
+			rule: ruleByName(t, "waf_enable_ha_with_zone_redundancy_mysql_high_availability_mode"),
 			content: `
 	variable "high_availability_mode" {
 		type    = string
@@ -37,7 +34,7 @@ func TestAzurermMySqlFlexibleServerZoneRedundancy(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermMySqlFlexibleServerZoneRedundancy(),
+			rule: ruleByName(t, "waf_enable_ha_with_zone_redundancy_mysql_high_availability_mode"),
 			content: `
     variable "high_availability_mode" {
 		type    = string
@@ -50,21 +47,21 @@ func TestAzurermMySqlFlexibleServerZoneRedundancy(t *testing.T) {
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermMySqlFlexibleServerZoneRedundancy(),
-					Message: "SameZone is an invalid attribute value of `mode` - expecting (one of) [ZoneRedundant]",
+					Rule:    ruleByName(t, "waf_enable_ha_with_zone_redundancy_mysql_high_availability_mode"),
+					Message: "`var.high_availability_mode (default) -> azurerm_mysql_flexible_server.example.high_availability.mode`: SameZone is an invalid attribute value of `mode` - expecting (one of) [ZoneRedundant]",
 				},
 			},
 		},
 		{
 			name: "missing block",
-			rule: wafRules.AzurermMySqlFlexibleServerZoneRedundancy(),
+			rule: ruleByName(t, "waf_enable_ha_with_zone_redundancy_mysql_high_availability_mode"),
 			content: `
 	resource "azurerm_mysql_flexible_server" "example" {
 
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermMySqlFlexibleServerZoneRedundancy(),
+					Rule:    ruleByName(t, "waf_enable_ha_with_zone_redundancy_mysql_high_availability_mode"),
 					Message: "The attribute `mode` must be specified",
 				},
 			},
@@ -87,8 +84,6 @@ func TestAzurermMySqlFlexibleServerZoneRedundancy(t *testing.T) {
 }
 
 func TestAzurermMySqlFlexibleServerCustomMaintenanceSchedule(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -97,7 +92,7 @@ func TestAzurermMySqlFlexibleServerCustomMaintenanceSchedule(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermMySqlFlexibleServerCustomMaintenanceSchedule(),
+			rule: ruleByName(t, "waf_enable_custom_maintenance_schedule_mysql_day_of_week"),
 			content: `
 	variable "maintenance_window" {
 		type    = string
@@ -112,7 +107,7 @@ func TestAzurermMySqlFlexibleServerCustomMaintenanceSchedule(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermMySqlFlexibleServerCustomMaintenanceSchedule(),
+			rule: ruleByName(t, "waf_enable_custom_maintenance_schedule_mysql_day_of_week"),
 			content: `
     variable "maintenance_window" {
 		type    = string
@@ -125,21 +120,21 @@ func TestAzurermMySqlFlexibleServerCustomMaintenanceSchedule(t *testing.T) {
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermMySqlFlexibleServerCustomMaintenanceSchedule(),
-					Message: "20 is an invalid attribute value of `day_of_week` - expecting (one of) [0 1 2 3 4 5 6]",
+					Rule:    ruleByName(t, "waf_enable_custom_maintenance_schedule_mysql_day_of_week"),
+					Message: "`var.maintenance_window (default) -> azurerm_mysql_flexible_server.example.maintenance_window.day_of_week`: 20 is an invalid attribute value of `day_of_week` - expecting (one of) [0 1 2 3 4 5 6]",
 				},
 			},
 		},
 		{
 			name: "missing block",
-			rule: wafRules.AzurermMySqlFlexibleServerCustomMaintenanceSchedule(),
+			rule: ruleByName(t, "waf_enable_custom_maintenance_schedule_mysql_day_of_week"),
 			content: `
 	resource "azurerm_mysql_flexible_server" "example" {
 
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermMySqlFlexibleServerCustomMaintenanceSchedule(),
+					Rule:    ruleByName(t, "waf_enable_custom_maintenance_schedule_mysql_day_of_week"),
 					Message: "The attribute `day_of_week` must be specified",
 				},
 			},
@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermPostgreSqlFlexibleServerZoneRedundancy(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,13 +19,14 @@ func TestAzurermPostgreSqlFlexibleServerZoneRedundancy(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermPostgreSqlFlexibleServerZoneRedundancy(),
+			rule: ruleByName(t, "waf_enable_ha_with_zone_redundancy_postgresql_high_availability_mode"),
 			content: `
 	variable "high_availability_mode" {
 		type    = string
 		default = "ZoneRedundant"
 	}
 	resource "azurerm_postgresql_flexible_server" "example" {
+		sku_name = "GP_Standard_D4s_v3"
 		high_availability {
 			mode = var.high_availability_mode
 		}
@@ -37,38 +35,48 @@ func TestAzurermPostgreSqlFlexibleServerZoneRedundancy(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermPostgreSqlFlexibleServerZoneRedundancy(),
+			rule: ruleByName(t, "waf_enable_ha_with_zone_redundancy_postgresql_high_availability_mode"),
 			content: `
     variable "high_availability_mode" {
 		type    = string
 		default = "SameZone"
 	}
 	resource "azurerm_postgresql_flexible_server" "example" {
+		sku_name = "GP_Standard_D4s_v3"
 		high_availability {
 			mode = var.high_availability_mode
 		}
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermPostgreSqlFlexibleServerZoneRedundancy(),
-					Message: "SameZone is an invalid attribute value of `mode` - expecting (one of) [ZoneRedundant]",
+					Rule:    ruleByName(t, "waf_enable_ha_with_zone_redundancy_postgresql_high_availability_mode"),
+					Message: "`var.high_availability_mode (default) -> azurerm_postgresql_flexible_server.example.high_availability.mode`: SameZone is an invalid attribute value of `mode` - expecting (one of) [ZoneRedundant]",
 				},
 			},
 		},
 		{
 			name: "missing block",
-			rule: wafRules.AzurermPostgreSqlFlexibleServerZoneRedundancy(),
+			rule: ruleByName(t, "waf_enable_ha_with_zone_redundancy_postgresql_high_availability_mode"),
 			content: `
 	resource "azurerm_postgresql_flexible_server" "example" {
-
+		sku_name = "MO_Standard_E4s_v3"
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermPostgreSqlFlexibleServerZoneRedundancy(),
+					Rule:    ruleByName(t, "waf_enable_ha_with_zone_redundancy_postgresql_high_availability_mode"),
 					Message: "The attribute `mode` must be specified",
 				},
 			},
 		},
+		{
+			name: "burstable sku without high availability",
+			rule: ruleByName(t, "waf_enable_ha_with_zone_redundancy_postgresql_high_availability_mode"),
+			content: `
+	resource "azurerm_postgresql_flexible_server" "example" {
+		sku_name = "B_Standard_B1ms"
+	}`,
+			expected: helper.Issues{},
+		},
 	}
 
 	filename := "main.tf"
@@ -87,8 +95,6 @@ func TestAzurermPostgreSqlFlexibleServerZoneRedundancy(t *testing.T) {
 }
 
 func TestAzurermPostgreSqlFlexibleServerCustomMaintenanceSchedule(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -97,7 +103,7 @@ func TestAzurermPostgreSqlFlexibleServerCustomMaintenanceSchedule(t *testing.T)
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermPostgreSqlFlexibleServerCustomMaintenanceSchedule(),
+			rule: ruleByName(t, "waf_enable_custom_maintenance_schedule_postgresql_day_of_week"),
 			content: `
 	variable "maintenance_window" {
 		type    = string
@@ -112,7 +118,7 @@ func TestAzurermPostgreSqlFlexibleServerCustomMaintenanceSchedule(t *testing.T)
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermPostgreSqlFlexibleServerCustomMaintenanceSchedule(),
+			rule: ruleByName(t, "waf_enable_custom_maintenance_schedule_postgresql_day_of_week"),
 			content: `
     variable "maintenance_window" {
 		type    = string
@@ -125,21 +131,21 @@ func TestAzurermPostgreSqlFlexibleServerCustomMaintenanceSchedule(t *testing.T)
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermPostgreSqlFlexibleServerCustomMaintenanceSchedule(),
-					Message: "20 is an invalid attribute value of `day_of_week` - expecting (one of) [0 1 2 3 4 5 6]",
+					Rule:    ruleByName(t, "waf_enable_custom_maintenance_schedule_postgresql_day_of_week"),
+					Message: "`var.maintenance_window (default) -> azurerm_postgresql_flexible_server.example.maintenance_window.day_of_week`: 20 is an invalid attribute value of `day_of_week` - expecting (one of) [0 1 2 3 4 5 6]",
 				},
 			},
 		},
 		{
 			name: "missing block",
-			rule: wafRules.AzurermPostgreSqlFlexibleServerCustomMaintenanceSchedule(),
+			rule: ruleByName(t, "waf_enable_custom_maintenance_schedule_postgresql_day_of_week"),
 			content: `
 	resource "azurerm_postgresql_flexible_server" "example" {
 
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermPostgreSqlFlexibleServerCustomMaintenanceSchedule(),
+					Rule:    ruleByName(t, "waf_enable_custom_maintenance_schedule_postgresql_day_of_week"),
 					Message: "The attribute `day_of_week` must be specified",
 				},
 			},
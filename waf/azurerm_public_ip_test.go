@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermPublicIpSku(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,7 +19,7 @@ func TestAzurermPublicIpSku(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermPublicIpSku(),
+			rule: ruleByName(t, "waf_pip_1_sku"),
 			content: `
 	resource "azurerm_public_ip" "example" {
 		sku = "Standard"
@@ -31,14 +28,14 @@ func TestAzurermPublicIpSku(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermPublicIpSku(),
+			rule: ruleByName(t, "waf_pip_1_sku"),
 			content: `
 	resource "azurerm_public_ip" "example" {
 		sku = "Periodic"
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermPublicIpSku(),
+					Rule:    ruleByName(t, "waf_pip_1_sku"),
 					Message: "Periodic is an invalid attribute value of `sku` - expecting (one of) [Standard]",
 				},
 			},
@@ -60,8 +57,6 @@ func TestAzurermPublicIpSku(t *testing.T) {
 	}
 }
 func TestAzurermPublicIpZones(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -70,27 +65,39 @@ func TestAzurermPublicIpZones(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermPublicIpZones(),
+			rule: ruleByName(t, "waf_pip_1_zones"),
 			content: `
 	resource "azurerm_public_ip" "example" {
+		sku   = "Standard"
 		zones = [1, 2, 3]
 	}`,
 			expected: helper.Issues{},
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermPublicIpZones(),
+			rule: ruleByName(t, "waf_pip_1_zones"),
 			content: `
 	resource "azurerm_public_ip" "example" {
+		sku   = "Standard"
 		zones = [1, 2]
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermPublicIpZones(),
+					Rule:    ruleByName(t, "waf_pip_1_zones"),
 					Message: "\"[1 2]\" is an invalid attribute value of `zones` - expecting (one of) [[1 2 3]]",
 				},
 			},
 		},
+		{
+			name: "basic sku",
+			rule: ruleByName(t, "waf_pip_1_zones"),
+			content: `
+	resource "azurerm_public_ip" "example" {
+		sku   = "Basic"
+		zones = [1]
+	}`,
+			expected: helper.Issues{},
+		},
 	}
 
 	filename := "main.tf"
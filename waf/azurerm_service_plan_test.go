@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermServicePlanZoneBalancingEnabled(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,27 +19,39 @@ func TestAzurermServicePlanZoneBalancingEnabled(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermServicePlanZoneBalancingEnabled(),
+			rule: ruleByName(t, "waf_asp_1_zone_balancing_enabled"),
 			content: `
 	resource "azurerm_service_plan" "example" {
+		sku_name               = "P1v3"
 		zone_balancing_enabled = true
 	}`,
 			expected: helper.Issues{},
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermServicePlanZoneBalancingEnabled(),
+			rule: ruleByName(t, "waf_asp_1_zone_balancing_enabled"),
 			content: `
 	resource "azurerm_service_plan" "example" {
+		sku_name               = "P1v3"
 		zone_balancing_enabled = false
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermServicePlanZoneBalancingEnabled(),
+					Rule:    ruleByName(t, "waf_asp_1_zone_balancing_enabled"),
 					Message: "false is an invalid attribute value of `zone_balancing_enabled` - expecting (one of) [true]",
 				},
 			},
 		},
+		{
+			name: "plan without zone support",
+			rule: ruleByName(t, "waf_asp_1_zone_balancing_enabled"),
+			content: `
+	resource "azurerm_service_plan" "example" {
+		sku_name               = "S1"
+		zone_balancing_enabled = false
+	}`,
+			expected: helper.Issues{},
+		},
 	}
 
 	filename := "main.tf"
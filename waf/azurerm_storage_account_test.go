@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermStorageAccountAccountReplicationType(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,7 +19,7 @@ func TestAzurermStorageAccountAccountReplicationType(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermStorageAccountAccountReplicationType(),
+			rule: ruleByName(t, "waf_st_1_account_replication_type"),
 			content: `
 	variable "account_replication_type" {
 		type    = list(string)
@@ -36,21 +33,21 @@ func TestAzurermStorageAccountAccountReplicationType(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermStorageAccountAccountReplicationType(),
+			rule: ruleByName(t, "waf_st_1_account_replication_type"),
 			content: `
 	resource "azurerm_storage_account" "example" {
 		account_replication_type = "LRS"
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermStorageAccountAccountReplicationType(),
+					Rule:    ruleByName(t, "waf_st_1_account_replication_type"),
 					Message: "LRS is an invalid attribute value of `account_replication_type` - expecting (one of) [GRS ZRS]",
 				},
 			},
 		},
 		{
 			name: "null value",
-			rule: wafRules.AzurermStorageAccountAccountReplicationType(),
+			rule: ruleByName(t, "waf_st_1_account_replication_type"),
 			content: `
 	variable "account_replication_type" {
 		type    = string
@@ -63,7 +60,7 @@ func TestAzurermStorageAccountAccountReplicationType(t *testing.T) {
 		},
 		{
 			name: "missing attribute",
-			rule: wafRules.AzurermStorageAccountAccountReplicationType(),
+			rule: ruleByName(t, "waf_st_1_account_replication_type"),
 			content: `
 	resource "azurerm_storage_account" "example" {
 	}`,
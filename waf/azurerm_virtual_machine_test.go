@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermVirtualMachineZoneUnknown(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,26 +19,29 @@ func TestAzurermVirtualMachineZoneUnknown(t *testing.T) {
 	}{
 		{
 			name: "zone specified",
-			rule: wafRules.AzurermVirtualMachineZoneUnknown(),
+			rule: ruleByName(t, "waf_vm_2_zone"),
 			content: `
 	resource "azurerm_virtual_machine" "example" {
 		zone = "1"
 	}`,
-			expected: helper.Issues{},
+			expected: helper.Issues{
+				{
+					Rule:    ruleByName(t, "waf_vm_2_zone"),
+					Message: "invalid attribute value of `zone` - expecting unknown",
+				},
+			},
 		},
 		{
-			name: "zone not specified",
-			rule: wafRules.AzurermVirtualMachineZoneUnknown(),
+			name: "zone from a variable",
+			rule: ruleByName(t, "waf_vm_2_zone"),
 			content: `
+	variable "zone" {
+		type = string
+	}
 	resource "azurerm_virtual_machine" "example" {
-		// no zone specified
+		zone = var.zone
 	}`,
-			expected: helper.Issues{
-				{
-					Rule:    wafRules.AzurermVirtualMachineZoneUnknown(),
-					Message: "The attribute `zone` must be specified",
-				},
-			},
+			expected: helper.Issues{},
 		},
 	}
 
@@ -6,14 +6,11 @@ import (
 	"github.com/prashantv/gostub"
 
 	"github.com/Azure/tflint-ruleset-avm/attrvalue"
-	"github.com/Azure/tflint-ruleset-avm/waf"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func TestAzurermVirtualNetworkGatewaySku(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -22,7 +19,7 @@ func TestAzurermVirtualNetworkGatewaySku(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermVirtualNetworkGatewaySku(),
+			rule: ruleByName(t, "waf_ergw_2_sku"),
 			content: `
 	variable "sku_type" {
 		type    = list(string)
@@ -36,14 +33,14 @@ func TestAzurermVirtualNetworkGatewaySku(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermVirtualNetworkGatewaySku(),
+			rule: ruleByName(t, "waf_ergw_2_sku"),
 			content: `
 	resource "azurerm_virtual_network_gateway" "example" {
 		sku = "ErGw4AZ"
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermVirtualNetworkGatewaySku(),
+					Rule:    ruleByName(t, "waf_ergw_2_sku"),
 					Message: "ErGw4AZ is an invalid attribute value of `sku` - expecting (one of) [ErGw1AZ ErGw2AZ ErGw3AZ VpnGw1AZ VpnGw2AZ VpnGw3AZ VpnGw4AZ VpnGw5AZ]",
 				},
 			},
@@ -66,8 +63,6 @@ func TestAzurermVirtualNetworkGatewaySku(t *testing.T) {
 }
 
 func TestAzurermVirtualNetworkGatewayVpnActiveActive(t *testing.T) {
-	wafRules := waf.WafRules{}
-
 	testCases := []struct {
 		name     string
 		rule     tflint.Rule
@@ -76,7 +71,7 @@ func TestAzurermVirtualNetworkGatewayVpnActiveActive(t *testing.T) {
 	}{
 		{
 			name: "correct setting",
-			rule: wafRules.AzurermVirtualNetworkGatewayVpnActiveActive(),
+			rule: ruleByName(t, "waf_plan_for_active_active_mode_with_vpn_gateways_active_active"),
 			content: `
 	resource "azurerm_virtual_network_gateway" "example" {
 		active_active = true
@@ -86,14 +81,14 @@ func TestAzurermVirtualNetworkGatewayVpnActiveActive(t *testing.T) {
 		},
 		{
 			name: "incorrect setting",
-			rule: wafRules.AzurermVirtualNetworkGatewayVpnActiveActive(),
+			rule: ruleByName(t, "waf_plan_for_active_active_mode_with_vpn_gateways_active_active"),
 			content: `
 	resource "azurerm_virtual_network_gateway" "example" {
 		active_active = false
 	}`,
 			expected: helper.Issues{
 				{
-					Rule:    wafRules.AzurermVirtualNetworkGatewayVpnActiveActive(),
+					Rule:    ruleByName(t, "waf_plan_for_active_active_mode_with_vpn_gateways_active_active"),
 					Message: "false is an invalid attribute value of `active_active` - expecting (one of) [true]",
 				},
 			},
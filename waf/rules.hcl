@@ -0,0 +1,385 @@
+# The WAF rules, one per checked attribute.
+#
+# Each rule is labelled with the ID of the Azure Proactive Resiliency Library (APRL) recommendation it enforces
+# and a short name for the check, the rule name is derived from both, e.g. `waf_pip_1_sku`.
+# APRL v2 recommendations have no short ID, they are identified by their anchor in the APRL page.
+#
+# kind is one of:
+# - allowed:  the attribute must be one of the expected values. A list of lists declares sets of values, in any order.
+# - unknown:  the attribute must not be known, e.g. it must come from a variable without a default.
+# - required: the attribute must be specified.
+# - pattern:  the attribute must match the `pattern` regular expression.
+# - range:    the attribute must be a number between `min` and `max`, inclusive unless `min_exclusive` or `max_exclusive` is set.
+# - forbidden: the attribute must not be one of the `forbidden` values, `recommendation` is suggested instead.
+# - not_allowed: the resource type must not be used, `recommendation` is suggested instead. It has no attribute,
+#   `block_types` limits it to "resource" or "data" blocks, and it has no valid examples.
+#
+# - required_keys:  the map or object attribute must have the `keys`.
+# - forbidden_keys: the map or object attribute must not have the `keys`.
+#
+# `attribute` can be followed by a path to a value inside a map or object attribute, e.g. "identity.type".
+# `arm_type` limits a rule on "azapi_resource" to an ARM type, e.g. "Microsoft.Storage/storageAccounts", whatever the API version,
+# and `attribute` is then a property path inside the body, e.g. "body.sku.name". A body set with jsonencode() is checked too.
+# `nested_block` can be a path of nested blocks separated by dots, e.g. "default_node_pool.upgrade_settings".
+#
+# A `when` block limits a rule to the resources where another top-level attribute, or a path inside it, has one of the `values`, or matches the `pattern`.
+# Resources where that attribute is not specified or not known are not checked.
+#
+# `since` is the plugin release the rule was introduced in.
+#
+# The valid and invalid examples are resource bodies, every example is checked by the tests.
+# They can use `var.example`, a variable without a default, for an unknown value.
+
+rule "AGW-1" "zones" {
+  resource_type = "azurerm_application_gateway"
+  attribute     = "zones"
+  kind          = "allowed"
+  expected      = [[1, 2, 3]]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/networking/application-gateway/#agw-1---set-a-minimum-instance-count-of-2"
+  since         = "0.1.0"
+  valid         = ["zones = [1, 2, 3]", "zones = [3, 1, 2]"]
+  invalid       = ["zones = [1, 2]"]
+}
+
+rule "AGW-4" "sku_name" {
+  resource_type = "azurerm_application_gateway"
+  nested_block  = "sku"
+  attribute     = "name"
+  kind          = "allowed"
+  expected      = ["Standard_v2", "WAF_v2"]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/networking/application-gateway/#agw-4---use-application-gw-v2-instead-of-v1"
+  since         = "0.1.0"
+  valid         = ["sku { name = \"WAF_v2\" }"]
+  invalid       = ["sku { name = \"Standard_Small\" }"]
+}
+
+rule "configure-continuous-backup-mode" "backup_type" {
+  resource_type = "azurerm_cosmosdb_account"
+  nested_block  = "backup"
+  attribute     = "type"
+  kind          = "allowed"
+  expected      = ["Continuous"]
+  must_exist    = true
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/DocumentDB/databaseAccounts/#configure-continuous-backup-mode"
+  since         = "0.1.0"
+  valid         = ["backup { type = \"Continuous\" }"]
+  invalid       = ["backup { type = \"Periodic\" }", "name = \"example\""]
+}
+
+rule "AKS-1" "zones" {
+  resource_type = "azurerm_kubernetes_cluster"
+  attribute     = "zones"
+  kind          = "allowed"
+  expected      = [[1, 2, 3]]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/container/aks/#aks-1---deploy-aks-cluster-across-availability-zones"
+  since         = "0.1.0"
+  valid         = ["zones = [1, 2, 3]"]
+  invalid       = ["zones = [1]"]
+}
+
+rule "use-standard-load-balancer-sku" "sku" {
+  resource_type = "azurerm_lb"
+  attribute     = "sku"
+  kind          = "allowed"
+  expected      = ["Standard"]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/Network/loadBalancers/#use-standard-load-balancer-sku"
+  since         = "0.1.0"
+  valid         = ["sku = \"Standard\""]
+  invalid       = ["sku = \"Basic\""]
+}
+
+rule "use-standard-load-balancer-sku" "azapi_sku_name" {
+  resource_type = "azapi_resource"
+  arm_type      = "Microsoft.Network/loadBalancers"
+  attribute     = "body.sku.name"
+  kind          = "allowed"
+  expected      = ["Standard"]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/Network/loadBalancers/#use-standard-load-balancer-sku"
+  since         = "0.2.0"
+  valid = [
+    "type = \"Microsoft.Network/loadBalancers@2023-09-01\"\nbody = { sku = { name = \"Standard\" } }",
+    "type = \"Microsoft.Network/loadBalancers@2023-09-01\"\nbody = jsonencode({ sku = { name = \"Standard\" } })",
+    "type = \"Microsoft.Network/publicIPAddresses@2023-09-01\"\nbody = { sku = { name = \"Basic\" } }",
+  ]
+  invalid = [
+    "type = \"Microsoft.Network/loadBalancers@2023-09-01\"\nbody = { sku = { name = \"Basic\" } }",
+    "type = \"Microsoft.Network/loadBalancers@2023-09-01\"\nbody = jsonencode({ sku = { name = \"Basic\" } })",
+  ]
+}
+
+rule "enable-ha-with-zone-redundancy" "mysql_high_availability_mode" {
+  resource_type = "azurerm_mysql_flexible_server"
+  nested_block  = "high_availability"
+  attribute     = "mode"
+  kind          = "allowed"
+  expected      = ["ZoneRedundant"]
+  must_exist    = true
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/DBforMySQL/flexibleServers/#enable-ha-with-zone-redundancy"
+  since         = "0.1.0"
+  valid         = ["high_availability { mode = \"ZoneRedundant\" }"]
+  invalid       = ["high_availability { mode = \"SameZone\" }", "name = \"example\""]
+}
+
+rule "enable-custom-maintenance-schedule" "mysql_day_of_week" {
+  resource_type = "azurerm_mysql_flexible_server"
+  nested_block  = "maintenance_window"
+  attribute     = "day_of_week"
+  kind          = "allowed"
+  expected      = ["0", "1", "2", "3", "4", "5", "6"]
+  must_exist    = true
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/DBforMySQL/flexibleServers/#enable-custom-maintenance-schedule"
+  since         = "0.1.0"
+  valid         = ["maintenance_window { day_of_week = \"0\" }"]
+  invalid       = ["maintenance_window { day_of_week = \"7\" }", "name = \"example\""]
+}
+
+rule "enable-ha-with-zone-redundancy" "postgresql_high_availability_mode" {
+  resource_type = "azurerm_postgresql_flexible_server"
+  nested_block  = "high_availability"
+  attribute     = "mode"
+  kind          = "allowed"
+  expected      = ["ZoneRedundant"]
+  must_exist    = true
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/DBforPostgreSQL/flexibleServers/#enable-ha-with-zone-redundancy"
+  since         = "0.1.0"
+  valid = [
+    "sku_name = \"GP_Standard_D4s_v3\"\nhigh_availability { mode = \"ZoneRedundant\" }",
+    "sku_name = \"B_Standard_B1ms\"",
+    "high_availability { mode = \"SameZone\" }",
+  ]
+  invalid = [
+    "sku_name = \"MO_Standard_E4s_v3\"\nhigh_availability { mode = \"SameZone\" }",
+    "sku_name = \"GP_Standard_D4s_v3\"",
+  ]
+
+  # High availability is only available for the General Purpose and Memory Optimized tiers.
+  when {
+    attribute = "sku_name"
+    pattern   = "^(GP|MO)_"
+  }
+}
+
+rule "enable-custom-maintenance-schedule" "postgresql_day_of_week" {
+  resource_type = "azurerm_postgresql_flexible_server"
+  nested_block  = "maintenance_window"
+  attribute     = "day_of_week"
+  kind          = "allowed"
+  expected      = ["0", "1", "2", "3", "4", "5", "6"]
+  must_exist    = true
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/DBforPostgreSQL/flexibleServers/#enable-custom-maintenance-schedule"
+  since         = "0.1.0"
+  valid         = ["maintenance_window { day_of_week = \"6\" }"]
+  invalid       = ["maintenance_window { day_of_week = \"7\" }", "name = \"example\""]
+}
+
+rule "PIP-1" "sku" {
+  resource_type = "azurerm_public_ip"
+  attribute     = "sku"
+  kind          = "allowed"
+  expected      = ["Standard"]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/networking/public-ip/#pip-1---use-standard-sku-and-zone-redundant-ips-when-applicable"
+  since         = "0.1.0"
+  valid         = ["sku = \"Standard\""]
+  invalid       = ["sku = \"Basic\""]
+}
+
+rule "PIP-1" "zones" {
+  resource_type = "azurerm_public_ip"
+  attribute     = "zones"
+  kind          = "allowed"
+  expected      = [[1, 2, 3]]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/networking/public-ip/#pip-1---use-standard-sku-and-zone-redundant-ips-when-applicable"
+  since         = "0.1.0"
+  valid         = ["sku = \"Standard\"\nzones = [1, 2, 3]", "sku = \"Basic\"\nzones = [1, 2]"]
+  invalid       = ["sku = \"Standard\"\nzones = [1, 2]"]
+
+  # Basic public IPs are not zonal, the sku rule reports them.
+  when {
+    attribute = "sku"
+    values    = ["Standard"]
+  }
+}
+
+rule "PIP-1" "azapi_sku_name" {
+  resource_type = "azapi_resource"
+  arm_type      = "Microsoft.Network/publicIPAddresses"
+  attribute     = "body.sku.name"
+  kind          = "allowed"
+  expected      = ["Standard"]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/networking/public-ip/#pip-1---use-standard-sku-and-zone-redundant-ips-when-applicable"
+  since         = "0.2.0"
+  valid         = ["type = \"Microsoft.Network/publicIPAddresses@2023-09-01\"\nbody = { sku = { name = \"Standard\" } }"]
+  invalid       = ["type = \"Microsoft.Network/publicIPAddresses@2023-09-01\"\nbody = { sku = { name = \"Basic\" } }"]
+}
+
+rule "PIP-1" "azapi_zones" {
+  resource_type = "azapi_resource"
+  arm_type      = "Microsoft.Network/publicIPAddresses"
+  attribute     = "body.zones"
+  kind          = "allowed"
+  expected      = [["1", "2", "3"]]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/networking/public-ip/#pip-1---use-standard-sku-and-zone-redundant-ips-when-applicable"
+  since         = "0.2.0"
+  valid = [
+    "type = \"Microsoft.Network/publicIPAddresses@2023-09-01\"\nbody = { sku = { name = \"Standard\" }, zones = [\"1\", \"2\", \"3\"] }",
+    "type = \"Microsoft.Network/publicIPAddresses@2023-09-01\"\nbody = { sku = { name = \"Basic\" }, zones = [\"1\"] }",
+  ]
+  invalid = ["type = \"Microsoft.Network/publicIPAddresses@2023-09-01\"\nbody = { sku = { name = \"Standard\" }, zones = [\"1\", \"2\"] }"]
+
+  when {
+    attribute = "body.sku.name"
+    values    = ["Standard"]
+  }
+}
+
+rule "ASP-1" "zone_balancing_enabled" {
+  resource_type = "azurerm_service_plan"
+  attribute     = "zone_balancing_enabled"
+  kind          = "allowed"
+  expected      = [true]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/web/app-service-plan/#asp-1---migrate-app-service-to-availability-zone-support"
+  since         = "0.1.0"
+  valid         = ["sku_name = \"P1v3\"\nzone_balancing_enabled = true", "sku_name = \"B1\"\nzone_balancing_enabled = false"]
+  invalid       = ["sku_name = \"P2mv3\"\nzone_balancing_enabled = false"]
+
+  # Zone balancing needs a Premium v3 plan.
+  when {
+    attribute = "sku_name"
+    pattern   = "^P[0-9]+m?v3$"
+  }
+}
+
+rule "ASP-1" "azapi_zone_redundant" {
+  resource_type = "azapi_resource"
+  arm_type      = "Microsoft.Web/serverfarms"
+  attribute     = "body.properties.zoneRedundant"
+  kind          = "allowed"
+  expected      = [true]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/web/app-service-plan/#asp-1---migrate-app-service-to-availability-zone-support"
+  since         = "0.2.0"
+  valid = [
+    "type = \"Microsoft.Web/serverfarms@2022-09-01\"\nbody = { sku = { name = \"P1v3\" }, properties = { zoneRedundant = true } }",
+    "type = \"Microsoft.Web/serverfarms@2022-09-01\"\nbody = { sku = { name = \"B1\" }, properties = { zoneRedundant = false } }",
+  ]
+  invalid = ["type = \"Microsoft.Web/serverfarms@2022-09-01\"\nbody = { sku = { name = \"P2mv3\" }, properties = { zoneRedundant = false } }"]
+
+  when {
+    attribute = "body.sku.name"
+    pattern   = "^P[0-9]+m?v3$"
+  }
+}
+
+rule "ST-1" "account_replication_type" {
+  resource_type = "azurerm_storage_account"
+  attribute     = "account_replication_type"
+  kind          = "allowed"
+  expected      = ["GRS", "ZRS"]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/storage/storage-account/#st-1---ensure-that-storage-accounts-are-zone-or-region-redundant"
+  since         = "0.1.0"
+  valid         = ["account_replication_type = \"ZRS\""]
+  invalid       = ["account_replication_type = \"LRS\""]
+}
+
+rule "ST-1" "azapi_sku_name" {
+  resource_type = "azapi_resource"
+  arm_type      = "Microsoft.Storage/storageAccounts"
+  attribute     = "body.sku.name"
+  kind          = "allowed"
+  expected      = ["Standard_GRS", "Standard_ZRS", "Premium_ZRS"]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/storage/storage-account/#st-1---ensure-that-storage-accounts-are-zone-or-region-redundant"
+  since         = "0.2.0"
+  valid         = ["type = \"Microsoft.Storage/storageAccounts@2023-01-01\"\nbody = { kind = \"StorageV2\", sku = { name = \"Standard_ZRS\" } }"]
+  invalid       = ["type = \"Microsoft.Storage/storageAccounts@2023-01-01\"\nbody = { kind = \"StorageV2\", sku = { name = \"Standard_LRS\" } }"]
+}
+
+rule "VM-2" "zone" {
+  resource_type = "azurerm_virtual_machine"
+  attribute     = "zone"
+  kind          = "unknown"
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/compute/virtual-machines/#vm-2---deploy-vms-across-availability-zones"
+  since         = "0.1.0"
+  valid         = ["zone = var.example"]
+  invalid       = ["zone = \"1\""]
+}
+
+rule "VM-2" "zones" {
+  resource_type = "azurerm_virtual_machine"
+  attribute     = "zones"
+  kind          = "unknown"
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/compute/virtual-machines/#vm-2---deploy-vms-across-availability-zones"
+  since         = "0.1.0"
+  valid         = ["zones = var.example"]
+  invalid       = ["zones = [\"1\"]"]
+}
+
+# The azurerm_windows_virtual_machine and azurerm_linux_virtual_machine resources do not support unmanaged disks, azurerm_virtual_machine does.
+# The azurerm_virtual_machine data source works with any virtual machine, so only the resource is reported.
+rule "use-managed-disks-for-vm-disks" "legacy_virtual_machine" {
+  resource_type  = "azurerm_virtual_machine"
+  kind           = "not_allowed"
+  block_types    = ["resource"]
+  recommendation = "azurerm_linux_virtual_machine or azurerm_windows_virtual_machine"
+  link           = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/Compute/virtualMachines/#use-managed-disks-for-vm-disks"
+  since          = "0.2.0"
+  invalid        = ["name = \"example\"", ""]
+}
+
+rule "mission-critical-workloads-should-consider-using-premium-or-ultra-disks" "windows_os_disk" {
+  resource_type = "azurerm_windows_virtual_machine"
+  nested_block  = "os_disk"
+  attribute     = "storage_account_type"
+  kind          = "allowed"
+  expected      = ["Premium_LRS", "Premium_ZRS", "PremiumV2_LRS"]
+  must_exist    = true
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/Compute/virtualMachines/#mission-critical-workloads-should-consider-using-premium-or-ultra-disks"
+  since         = "0.1.0"
+  valid         = ["os_disk { storage_account_type = \"Premium_ZRS\" }"]
+  invalid       = ["os_disk { storage_account_type = \"Standard_LRS\" }", "os_disk { caching = \"ReadWrite\" }"]
+}
+
+rule "mission-critical-workloads-should-consider-using-premium-or-ultra-disks" "linux_os_disk" {
+  resource_type = "azurerm_linux_virtual_machine"
+  nested_block  = "os_disk"
+  attribute     = "storage_account_type"
+  kind          = "allowed"
+  expected      = ["Premium_LRS", "Premium_ZRS", "PremiumV2_LRS"]
+  must_exist    = true
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/Compute/virtualMachines/#mission-critical-workloads-should-consider-using-premium-or-ultra-disks"
+  since         = "0.1.0"
+  valid         = ["os_disk { storage_account_type = \"Premium_LRS\" }"]
+  invalid       = ["os_disk { storage_account_type = \"StandardSSD_LRS\" }", "os_disk { caching = \"ReadWrite\" }"]
+}
+
+rule "mission-critical-workloads-should-consider-using-premium-or-ultra-disks" "managed_disk" {
+  resource_type = "azurerm_managed_disk"
+  attribute     = "storage_account_type"
+  kind          = "allowed"
+  expected      = ["Premium_LRS", "Premium_ZRS", "PremiumV2_LRS", "UltraSSD_LRS"]
+  must_exist    = true
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/Compute/virtualMachines/#mission-critical-workloads-should-consider-using-premium-or-ultra-disks"
+  since         = "0.1.0"
+  valid         = ["storage_account_type = \"UltraSSD_LRS\""]
+  invalid       = ["storage_account_type = \"Standard_LRS\"", "name = \"example\""]
+}
+
+rule "ERGW-2" "sku" {
+  resource_type = "azurerm_virtual_network_gateway"
+  attribute     = "sku"
+  kind          = "allowed"
+  expected      = ["ErGw1AZ", "ErGw2AZ", "ErGw3AZ", "VpnGw1AZ", "VpnGw2AZ", "VpnGw3AZ", "VpnGw4AZ", "VpnGw5AZ"]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library/services/networking/expressroute-gateway/#ergw-2---use-zone-redundant-gateway-skus"
+  since         = "0.1.0"
+  valid         = ["sku = \"VpnGw2AZ\""]
+  invalid       = ["sku = \"ErGw1\""]
+}
+
+rule "plan-for-active-active-mode-with-vpn-gateways" "active_active" {
+  resource_type = "azurerm_virtual_network_gateway"
+  attribute     = "active_active"
+  kind          = "allowed"
+  expected      = [true]
+  link          = "https://azure.github.io/Azure-Proactive-Resiliency-Library-v2/azure-resources/Network/virtualNetworkGateways/#plan-for-active-active-mode-with-vpn-gateways"
+  since         = "0.1.0"
+  valid         = ["active_active = true"]
+  invalid       = ["active_active = false"]
+}
@@ -1,41 +1,156 @@
 // Package waf contains the rules for Well Architected Alignment.
-// To add a new rule, create a new file and add a new function that returns a new rule.
-// Then add the rule to the Rules slice.
+// The rules are declared as data in rules.hcl, one per checked attribute, and built with attrvalue.NewRuleFromSpec.
+// To add a rule, add a rule block with valid and invalid examples to rules.hcl, the tests check every example.
 package waf
 
 import (
-	"reflect"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/hashicorp/hcl/v2/hclsimple"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
 )
 
-// WafRules is a helper struct. Methods are created on this type that generate the rules for the WAF package.
-// We then use reflection to automatically generate a slice of the rules to add the the ruleset.
-type WafRules struct{}
+//go:embed rules.hcl
+var rulesFile []byte
 
-// GetRules uses reflection to iterate over all the methods of the WafRules struct and add them to a slice of Rules to be included in the ruleset.
-// See `GetRules()` implementation for more detail.
-func GetRules() []tflint.Rule {
-	// Create a slice to add the rules to
-	rules := []tflint.Rule{}
+// RuleSpec is a WAF rule as declared in rules.hcl.
+type RuleSpec struct {
+	AprlID         string    `hcl:"aprl_id,label"` // The ID of the APRL recommendation, or its anchor for APRL v2 recommendations.
+	Check          string    `hcl:"check,label"`   // A short name for the check, unique within the recommendation.
+	ResourceType   string    `hcl:"resource_type"`
+	ArmType        string    `hcl:"arm_type,optional"` // The ARM type of the checked azapi_resource blocks, e.g. "Microsoft.Network/loadBalancers".
+	NestedBlock    *string   `hcl:"nested_block,optional"`
+	Attribute      string    `hcl:"attribute,optional"` // Not set for the not_allowed kind.
+	Kind           string    `hcl:"kind"`               // See the attrvalue.Kind constants.
+	BlockTypes     []string  `hcl:"block_types,optional"`
+	Expected       cty.Value `hcl:"expected,optional"`
+	Forbidden      cty.Value `hcl:"forbidden,optional"`
+	Recommendation string    `hcl:"recommendation,optional"`
+	Pattern        string    `hcl:"pattern,optional"`
+	Min            *float64  `hcl:"min,optional"`
+	MinExclusive   bool      `hcl:"min_exclusive,optional"`
+	Max            *float64  `hcl:"max,optional"`
+	MaxExclusive   bool      `hcl:"max_exclusive,optional"`
+	Keys           []string  `hcl:"keys,optional"` // The map or object keys of the required_keys and forbidden_keys kinds.
+	MustExist      bool      `hcl:"must_exist,optional"`
+	When           *When     `hcl:"when,block"` // Limits the rule to the resources that meet the condition.
+	Link           string    `hcl:"link"`
+	Since          string    `hcl:"since"`            // The plugin release the rule was introduced in.
+	Valid          []string  `hcl:"valid,optional"`   // Resource bodies that pass the rule, none for the not_allowed kind.
+	Invalid        []string  `hcl:"invalid,optional"` // Resource bodies that each fail the rule with a single issue.
+}
 
-	// Get an instance of the WefRules struct we can use to iterate and call functions on
-	wafRulesInstance := reflect.ValueOf(WafRules{})
+// When is the condition of a RuleSpec, on another attribute of the same resource.
+type When struct {
+	Attribute string    `hcl:"attribute"`
+	Values    cty.Value `hcl:"values,optional"`
+	Pattern   string    `hcl:"pattern,optional"`
+}
 
-	// Iterate over all the functions of the WafRules struct
-	for i := 0; i < wafRulesInstance.NumMethod(); i++ {
-		// Get an instance of the function
-		methodInstance := wafRulesInstance.Method(i)
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
 
-		// Call the function with no parameters (WAF rules don't require them), this returns a slice of outputs
-		ruleOutputs := methodInstance.Call([]reflect.Value{})
+// Name returns the name of the rule, derived from the APRL ID and the check, e.g. `waf_pip_1_sku`.
+func (s RuleSpec) Name() string {
+	slug := func(s string) string {
+		return strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(s), "_"), "_")
+	}
+	return fmt.Sprintf("waf_%s_%s", slug(s.AprlID), slug(s.Check))
+}
 
-		// Cast the first (and only for WAF rules) output to its proper type
-		rule := ruleOutputs[0].Interface().(tflint.Rule)
+// NewRule returns the rule declared by the spec.
+func (s RuleSpec) NewRule() (tflint.Rule, error) {
+	var condition *attrvalue.Condition
+	if s.When != nil {
+		var err error
+		if condition, err = attrvalue.NewCondition(s.When.Attribute, s.When.Values, s.When.Pattern); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Name(), err)
+		}
+	}
+	rule, err := attrvalue.NewRuleFromSpec(attrvalue.Spec{
+		Name:            s.Name(),
+		ResourceType:    s.ResourceType,
+		ArmType:         s.ArmType,
+		NestedBlockType: s.NestedBlock,
+		AttributeName:   s.Attribute,
+		Kind:            s.Kind,
+		BlockTypes:      s.BlockTypes,
+		ExpectedValues:  s.Expected,
+		ForbiddenValues: s.Forbidden,
+		Recommendation:  s.Recommendation,
+		Pattern:         s.Pattern,
+		Min:             attrvalue.NewBound(s.Min, s.MinExclusive),
+		Max:             attrvalue.NewBound(s.Max, s.MaxExclusive),
+		Keys:            s.Keys,
+		Condition:       condition,
+		MustExist:       s.MustExist,
+		Link:            s.Link,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &recommendationRule{
+		Rule:   rule,
+		aprlID: s.AprlID,
+	}, nil
+}
+
+// ruleSpecs contains the specs declared in rules.hcl, validated when the package is loaded.
+var ruleSpecs = func() []RuleSpec {
+	specs, err := parseRuleSpecs("rules.hcl", rulesFile)
+	if err != nil {
+		panic(err)
+	}
+	return specs
+}()
 
-		// Apend the rule to slice
-		rules = append(rules, rule)
+// parseRuleSpecs decodes the rule specs and checks that they build into rules with unique names.
+func parseRuleSpecs(filename string, src []byte) ([]RuleSpec, error) {
+	var file struct {
+		Rules []RuleSpec `hcl:"rule,block"`
+	}
+	if err := hclsimple.Decode(filename, src, nil, &file); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(file.Rules))
+	for _, s := range file.Rules {
+		if names[s.Name()] {
+			return nil, fmt.Errorf("%s: duplicate rule %s", filename, s.Name())
+		}
+		names[s.Name()] = true
+		if _, err := s.NewRule(); err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
 	}
+	return file.Rules, nil
+}
+
+// RuleSpecs returns the specs of all the WAF rules, in the order of rules.hcl.
+func RuleSpecs() []RuleSpec {
+	return slices.Clone(ruleSpecs)
+}
+
+// Recommendation is implemented by the WAF rules, to map them to the APRL recommendation they enforce.
+type Recommendation interface {
+	AprlID() string
+}
+
+var _ Recommendation = new(recommendationRule)
+
+type recommendationRule struct {
+	tflint.Rule
+	aprlID string
+}
+
+func (r *recommendationRule) AprlID() string {
+	return r.aprlID
+}
 
-	return rules
+func (r *recommendationRule) Unwrap() tflint.Rule {
+	return r.Rule
 }
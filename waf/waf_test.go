@@ -1,12 +1,19 @@
 package waf_test
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
+	"github.com/Azure/tflint-ruleset-avm/attrvalue"
+	"github.com/Azure/tflint-ruleset-avm/common"
 	"github.com/Azure/tflint-ruleset-avm/waf"
+	"github.com/prashantv/gostub"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func mockFs(c string) afero.Afero {
@@ -15,7 +22,68 @@ func mockFs(c string) afero.Afero {
 	return afero.Afero{Fs: fs}
 }
 
-func TestGetRules(t *testing.T) {
-	rules := waf.GetRules()
-	assert.Truef(t, len(rules) > 0, "rules should not be empty")
+// ruleByName returns the attribute value rule of the WAF rule with the given name, which is the rule its issues are emitted by.
+func ruleByName(t *testing.T, name string) tflint.Rule {
+	for _, spec := range waf.RuleSpecs() {
+		if spec.Name() == name {
+			rule, err := spec.NewRule()
+			require.NoError(t, err)
+			return common.UnwrapRule(rule)
+		}
+	}
+	t.Fatalf("rule %s not found", name)
+	return nil
+}
+
+func TestRuleSpecs(t *testing.T) {
+	specs := waf.RuleSpecs()
+	assert.Truef(t, len(specs) > 0, "rules should not be empty")
+	for _, spec := range specs {
+		_, err := spec.NewRule()
+		assert.NoError(t, err, spec.Name())
+	}
+}
+
+func TestRuleNames(t *testing.T) {
+	assert.Equal(t, "waf_pip_1_sku", ruleByName(t, "waf_pip_1_sku").Name())
+	for _, spec := range waf.RuleSpecs() {
+		rule, err := spec.NewRule()
+		require.NoError(t, err)
+		assert.Regexp(t, `^waf_[a-z0-9_]+$`, rule.Name())
+		assert.NotEmpty(t, rule.(waf.Recommendation).AprlID())
+	}
+}
+
+// TestRuleSpecExamples checks every rule against the valid and invalid examples declared in rules.hcl.
+func TestRuleSpecExamples(t *testing.T) {
+	for _, spec := range waf.RuleSpecs() {
+		spec := spec
+		if spec.Kind != attrvalue.KindNotAllowed {
+			require.NotEmptyf(t, spec.Valid, "%s must have valid examples", spec.Name())
+		}
+		require.NotEmptyf(t, spec.Invalid, "%s must have invalid examples", spec.Name())
+
+		examples := map[string][]string{"valid": spec.Valid, "invalid": spec.Invalid}
+		for kind, bodies := range examples {
+			for i, body := range bodies {
+				kind, body := kind, body
+				t.Run(fmt.Sprintf("%s/%s_%d", spec.Name(), kind, i), func(t *testing.T) {
+					content := fmt.Sprintf("variable \"example\" {}\n\nresource %q \"example\" {\n%s\n}\n", spec.ResourceType, body)
+					runner := helper.TestRunner(t, map[string]string{"main.tf": content})
+					stub := gostub.Stub(&attrvalue.AppFs, mockFs(content))
+					defer stub.Reset()
+
+					rule, err := spec.NewRule()
+					require.NoError(t, err)
+					require.NoError(t, rule.Check(runner))
+
+					if kind == "valid" {
+						assert.Empty(t, runner.Issues)
+						return
+					}
+					assert.Len(t, runner.Issues, 1)
+				})
+			}
+		}
+	}
 }